@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/config"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/providers"
+	"github.com/tenderly/tenderly-cli/rest"
+)
+
+// loadContractABIs loads ABIs of contracts in the build directory of configured deployment provider
+// and, if r is set, of contracts pushed to project. ABIs that can't be loaded are skipped with a message.
+// Nothing is loaded if no trigger of actions has conditions checked against ABIs, as loading can be slow.
+func loadContractABIs(r *rest.Rest, projectSlug string, projectActions *actionsModel.ProjectActions) actionsModel.ContractABIs {
+	abis := make(actionsModel.ContractABIs)
+	if !specsUseABIs(projectActions) {
+		return abis
+	}
+
+	err := addLocalContractABIs(abis)
+	if err != nil {
+		logrus.Info(commands.Colorizer.Sprintf(
+			"\nSkipping local contract ABIs: %s",
+			commands.Colorizer.Blue(err.Error()),
+		))
+	}
+
+	if r != nil {
+		err = addProjectContractABIs(abis, r, projectSlug)
+		if err != nil {
+			logrus.Info(commands.Colorizer.Sprintf(
+				"\nSkipping ABIs of contracts in project %s: %s",
+				commands.Colorizer.Bold(projectSlug),
+				commands.Colorizer.Blue(err.Error()),
+			))
+		}
+	}
+
+	return abis
+}
+
+func specsUseABIs(projectActions *actionsModel.ProjectActions) bool {
+	for _, spec := range projectActions.Specs {
+		if spec.UsesABI() {
+			return true
+		}
+	}
+	return false
+}
+
+// loadBuildContractABIs loads ABIs for builds of commands which work without Tenderly. Contracts pushed to project
+// are loaded if logged in, so types generated by these builds match types generated by tenderly actions build.
+func loadBuildContractABIs(projectActions *actionsModel.ProjectActions) actionsModel.ContractABIs {
+	var client *rest.Rest
+	if config.IsLoggedIn() && specsUseABIs(projectActions) {
+		client = commands.NewRest()
+	}
+	return loadContractABIs(client, environmentProjectSlug(), projectActions)
+}
+
+// addLocalContractABIs adds ABIs from the build directory of deployment provider set in tenderly.yaml,
+// or detected in project directory. Provider is not prompted for and tenderly.yaml is not rewritten.
+func addLocalContractABIs(abis actionsModel.ContractABIs) error {
+	provider := commands.DetectProvider(providers.DeploymentProviderName(config.MaybeGetString(config.Provider)))
+	if provider == nil {
+		return nil
+	}
+
+	providerConfig, err := provider.MustGetConfig()
+	if err != nil {
+		return err
+	}
+	contracts, _, err := provider.GetContracts(providerConfig.AbsoluteBuildDirectoryPath(), nil)
+	if err != nil {
+		return err
+	}
+
+	for _, contract := range contracts {
+		if contract.Abi == nil {
+			continue
+		}
+		abiJSON, err := json.Marshal(contract.Abi)
+		if err != nil {
+			return errors.Wrapf(err, "failed encoding abi of contract %s", contract.Name)
+		}
+		for networkID, network := range contract.Networks {
+			err = abis.Add(networkID, network.Address, abiJSON)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addProjectContractABIs adds ABIs of contracts pushed to project.
+func addProjectContractABIs(abis actionsModel.ContractABIs, r *rest.Rest, projectSlug string) error {
+	response, err := r.Contract.GetContracts(projectSlug)
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+
+	for _, contract := range response.Contracts {
+		if contract.Abi == "" {
+			continue
+		}
+		err = abis.Add(contract.NetworkID, contract.Address, []byte(contract.Abi))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,434 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/manifoldco/promptui"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	"github.com/tenderly/tenderly-cli/config"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/providers"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+const (
+	addFilterContract = "any call to contract"
+	addFilterFunction = "function call"
+	addFilterEvent    = "emitted event"
+	addAnyStatus      = "any"
+)
+
+var actionNameRe = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9_-]*$")
+
+var addActionTypescript = `import {
+	ActionFn,
+	Context,
+	Event,
+	%[2]s,
+} from '@tenderly/actions';
+
+export const %[1]s: ActionFn = async (context: Context, event: Event) => {
+	let %[3]s = event as %[2]s;
+	console.log(%[3]s);
+}
+`
+
+var addActionJavascript = `const %[1]s = async (context, event) => {
+	console.log(event);
+}
+module.exports = { %[1]s };
+`
+
+func init() {
+	actionsCmd.AddCommand(addCmd)
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add [action name]",
+	Short: "Add action with a trigger built step by step",
+	Long: "Guides you through building action trigger: trigger type, networks, contracts pushed to project, " +
+		"functions and events from their ABIs, statuses, cron or interval. " +
+		"Validated action is added to tenderly.yaml and a function handling the trigger event is created in sources.",
+	Args: cobra.MaximumNArgs(1),
+	Run:  addFunc,
+}
+
+func addFunc(cmd *cobra.Command, args []string) {
+	commands.CheckLogin()
+	r = commands.NewRest()
+
+	allActions := MustGetActions()
+	projectSlug = chooseConfiguredProject(r, allActions)
+	projectActions := mustGetProjectActions(allActions, projectSlug)
+
+	language := LanguageJavaScript
+	handlerDir := projectActions.Sources
+	if util.TsConfigExists(projectActions.Sources) {
+		language = LanguageTypeScript
+		tsconfig := util.MustLoadTsConfig(projectActions.Sources)
+		if tsconfig.CompilerOptions.RootDir != nil {
+			handlerDir = filepath.Join(projectActions.Sources, *tsconfig.CompilerOptions.RootDir)
+		}
+	}
+	extension := "js"
+	if language == LanguageTypeScript {
+		extension = "ts"
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = args[0]
+		err := validateActionName(projectActions, handlerDir, extension, name)
+		if err != nil {
+			userError.LogErrorf(
+				"invalid action name: %s",
+				userError.NewUserError(err, commands.Colorizer.Sprintf("Invalid action name: %s", commands.Colorizer.Red(err.Error()))),
+			)
+			os.Exit(1)
+		}
+	} else {
+		name = mustPrompt("Action name", "", func(input string) error {
+			return validateActionName(projectActions, handlerDir, extension, input)
+		})
+	}
+
+	triggerType := mustSelect("Trigger type", actionsModel.TriggerTypes)
+	trigger := actionsModel.TriggerUnparsed{Type: triggerType}
+	abis := make(actionsModel.ContractABIs)
+	switch triggerType {
+	case actionsModel.PeriodicType:
+		trigger.Periodic = promptPeriodicTrigger()
+	case actionsModel.WebhookType:
+		authenticated := mustSelect("Authenticated webhook", []string{"true", "false"}) == "true"
+		trigger.Webhook = map[string]interface{}{"authenticated": authenticated}
+	case actionsModel.BlockType:
+		trigger.Block = map[string]interface{}{
+			"network": promptNetworks(),
+			"blocks":  promptPositiveInt("Run every number of blocks", "10"),
+		}
+	case actionsModel.TransactionType:
+		trigger.Transaction = promptTransactionTrigger(abis)
+	case actionsModel.AlertType:
+		trigger.Alert = map[string]interface{}{}
+	}
+
+	functionName := lowerCamelCase(name) + "Fn"
+	spec := &actionsModel.ActionSpec{
+		Function:      fmt.Sprintf("%s:%s", name, functionName),
+		Trigger:       trigger,
+		ExecutionType: mustSelect("Execution type", []string{actionsModel.ParallelExecutionType, actionsModel.SequentialExecutionType}),
+	}
+	description := mustPrompt("Description (optional)", "", nil)
+	if description != "" {
+		spec.Description = &description
+	}
+	mustValidateAddedSpec(name, spec, abis)
+
+	if projectActions.Specs == nil {
+		projectActions.Specs = make(actionsModel.NamedActionSpecs)
+	}
+	projectActions.Specs[name] = spec
+	config.MustWriteActionsInit(projectSlug, projectActions)
+
+	handlerPath := filepath.Join(handlerDir, fmt.Sprintf("%s.%s", name, extension))
+	util.CreateFileWithContent(handlerPath, handlerStub(language, triggerType, functionName))
+
+	logrus.Info(commands.Colorizer.Sprintf(
+		"\nAdded action %s to %s. Function %s created in %s.",
+		commands.Colorizer.Bold(commands.Colorizer.Green(name)),
+		commands.Colorizer.Bold(commands.Colorizer.Green("tenderly.yaml")),
+		commands.Colorizer.Bold(functionName),
+		commands.Colorizer.Bold(commands.Colorizer.Green(handlerPath)),
+	))
+}
+
+func validateActionName(projectActions *actionsModel.ProjectActions, handlerDir string, extension string, name string) error {
+	if !actionNameRe.MatchString(name) {
+		return errors.Errorf("%s must start with a letter and contain only letters, digits, - and _", name)
+	}
+	if _, exists := projectActions.Specs[name]; exists {
+		return errors.Errorf("action %s already exists", name)
+	}
+	handlerPath := filepath.Join(handlerDir, fmt.Sprintf("%s.%s", name, extension))
+	if util.ExistFile(handlerPath) {
+		return errors.Errorf("file %s already exists", handlerPath)
+	}
+	return nil
+}
+
+func promptPeriodicTrigger() map[string]interface{} {
+	if mustSelect("Schedule with", []string{"interval", "cron"}) == "interval" {
+		return map[string]interface{}{"interval": mustSelect("Interval", actionsModel.Intervals)}
+	}
+	cron := mustPrompt("Cron expression", "0 * * * *", func(input string) error {
+		_, err := actionsModel.CronParser.Parse(input)
+		return err
+	})
+	return map[string]interface{}{"cron": cron}
+}
+
+func promptTransactionTrigger(abis actionsModel.ContractABIs) map[string]interface{} {
+	var statuses []string
+	for _, status := range generatedActions.TransactionStatus_Values() {
+		statuses = append(statuses, strings.ToLower(string(status)))
+	}
+	transactionStatus := mustSelect("Transaction status", statuses)
+
+	networks := promptNetworks()
+	filter := map[string]interface{}{"network": networks}
+
+	filterStatuses := []string{addAnyStatus}
+	for _, status := range generatedActions.Status_Values() {
+		filterStatuses = append(filterStatuses, strings.ToLower(string(status)))
+	}
+	if status := mustSelect("Execution status of transaction", filterStatuses); status != addAnyStatus {
+		filter["status"] = status
+	}
+
+	contract := promptContract(networks, abis)
+	contractValue := map[string]interface{}{"address": contract.Address}
+	filterType := mustSelect("Trigger on", []string{addFilterFunction, addFilterEvent, addFilterContract})
+	contractABI := abis.Find(contract.Address, []string{contract.NetworkID})
+	switch filterType {
+	case addFilterContract:
+		filter["contract"] = contractValue
+	case addFilterFunction:
+		var names []string
+		if contractABI != nil {
+			for _, method := range contractABI.Methods {
+				names = append(names, method.RawName)
+			}
+		}
+		filter["function"] = map[string]interface{}{
+			"contract": contractValue,
+			"name":     selectOrPromptName("Function", names),
+		}
+	case addFilterEvent:
+		var names []string
+		if contractABI != nil {
+			for _, event := range contractABI.Events {
+				names = append(names, event.RawName)
+			}
+		}
+		filter["eventEmitted"] = map[string]interface{}{
+			"contract": contractValue,
+			"name":     selectOrPromptName("Event", names),
+		}
+	}
+
+	return map[string]interface{}{
+		"status":  []string{transactionStatus},
+		"filters": []interface{}{filter},
+	}
+}
+
+// promptContract selects one of contracts pushed to project on networks, or prompts for address if there are none.
+// ABI of selected contract is added to abis.
+func promptContract(networks []int, abis actionsModel.ContractABIs) providers.ApiContract {
+	var contracts []providers.ApiContract
+	response, err := r.Contract.GetContracts(projectSlug)
+	if err == nil && response.Error != nil {
+		err = response.Error
+	}
+	if err != nil {
+		logrus.Info(commands.Colorizer.Sprintf(
+			"Failed fetching contracts of project %s: %s",
+			commands.Colorizer.Bold(projectSlug),
+			commands.Colorizer.Blue(err.Error()),
+		))
+	} else {
+		for _, contract := range response.Contracts {
+			for _, network := range networks {
+				if contract.NetworkID == strconv.Itoa(network) {
+					contracts = append(contracts, contract)
+				}
+			}
+		}
+	}
+	sort.Slice(contracts, func(i, j int) bool {
+		if contracts[i].Name != contracts[j].Name {
+			return contracts[i].Name < contracts[j].Name
+		}
+		return contracts[i].NetworkID < contracts[j].NetworkID
+	})
+
+	if len(contracts) == 0 {
+		logrus.Info("No contracts pushed to project on selected networks, functions and events can't be listed.")
+		address := mustPrompt("Contract address", "", func(input string) error {
+			if !actionsModel.AddressRe.MatchString(strings.ToLower(input)) {
+				return errors.Errorf(actionsModel.MsgAddressDoesNotMatchRegex, input, actionsModel.AddressRegex)
+			}
+			return nil
+		})
+		return providers.ApiContract{Address: address, NetworkID: strconv.Itoa(networks[0])}
+	}
+
+	items := make([]string, len(contracts))
+	for i, contract := range contracts {
+		items[i] = fmt.Sprintf("%s %s (network %s)", contract.Name, contract.Address, contract.NetworkID)
+	}
+	prompt := promptui.Select{
+		Label: "Contract",
+		Items: items,
+	}
+	index, _, err := prompt.Run()
+	if err != nil {
+		userError.LogErrorf("prompt contract failed: %s", err)
+		os.Exit(1)
+	}
+
+	contract := contracts[index]
+	if contract.Abi != "" {
+		err = abis.Add(contract.NetworkID, contract.Address, []byte(contract.Abi))
+		if err != nil {
+			logrus.Debugf("failed parsing abi of contract %s: %s", contract.Address, err)
+		}
+	}
+	return contract
+}
+
+// selectOrPromptName selects one of names, or prompts for a name if there are none.
+func selectOrPromptName(label string, names []string) string {
+	if len(names) == 0 {
+		return mustPrompt(fmt.Sprintf("%s name", label), "", func(input string) error {
+			if strings.TrimSpace(input) == "" {
+				return errors.New("value must not be empty")
+			}
+			return nil
+		})
+	}
+
+	unique := make(map[string]bool)
+	var items []string
+	for _, name := range names {
+		if !unique[name] {
+			unique[name] = true
+			items = append(items, name)
+		}
+	}
+	sort.Strings(items)
+	return mustSelect(label, items)
+}
+
+func promptNetworks() []int {
+	input := mustPrompt("Network IDs, comma separated", "1", func(input string) error {
+		_, err := parseNetworks(input)
+		return err
+	})
+	networks, _ := parseNetworks(input)
+	return networks
+}
+
+func parseNetworks(input string) ([]int, error) {
+	var networks []int
+	for _, part := range strings.Split(input, ",") {
+		network, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || network <= 0 {
+			return nil, errors.Errorf("network id %s must be a positive number", strings.TrimSpace(part))
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+func promptPositiveInt(label string, defaultValue string) int {
+	input := mustPrompt(label, defaultValue, func(input string) error {
+		value, err := strconv.Atoi(input)
+		if err != nil || value <= 0 {
+			return errors.New("value must be a positive number")
+		}
+		return nil
+	})
+	value, _ := strconv.Atoi(input)
+	return value
+}
+
+// mustValidateAddedSpec parses and validates trigger of added spec, including its schedule and ABIs of selected contracts.
+func mustValidateAddedSpec(name string, spec *actionsModel.ActionSpec, abis actionsModel.ContractABIs) {
+	err := spec.Parse()
+	if err != nil {
+		userError.LogErrorf("failed parsing added trigger: %s", err)
+		os.Exit(1)
+	}
+
+	ctx := actionsModel.NewValidatorContext(name, "trigger")
+	response := spec.TriggerParsed.Validate(ctx)
+	if len(response.Errors) == 0 {
+		response.Merge(spec.TriggerParsed.ValidateSchedule(ctx, time.Now()))
+		response.Merge(spec.TriggerParsed.ValidateABI(ctx, abis))
+	}
+	for _, msg := range response.Infos {
+		logrus.Info(commands.Colorizer.Blue(msg))
+	}
+	if len(response.Errors) > 0 {
+		for _, msg := range response.Errors {
+			logrus.Info(commands.Colorizer.Red(msg))
+		}
+		logrus.Error(commands.Colorizer.Bold(commands.Colorizer.Red("Built trigger is not valid, action is not added")))
+		os.Exit(1)
+	}
+}
+
+// handlerStub returns source of action function handling event of trigger type.
+func handlerStub(language string, triggerType string, functionName string) string {
+	if language == LanguageJavaScript {
+		return fmt.Sprintf(addActionJavascript, functionName)
+	}
+	eventType := actionsModel.TriggerEventTypes[triggerType]
+	return fmt.Sprintf(addActionTypescript, functionName, eventType, lowerCamelCase(eventType))
+}
+
+// lowerCamelCase converts name separated by - or _ to lower camel case, e.g. my-action to myAction.
+func lowerCamelCase(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	for i, part := range parts {
+		if i == 0 {
+			parts[i] = strings.ToLower(part[:1]) + part[1:]
+		} else {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func mustSelect(label string, items []string) string {
+	prompt := promptui.Select{
+		Label: label,
+		Items: items,
+	}
+	_, result, err := prompt.Run()
+	if err != nil {
+		userError.LogErrorf("prompt failed: %s", err)
+		os.Exit(1)
+	}
+	return result
+}
+
+func mustPrompt(label string, defaultValue string, validate promptui.ValidateFunc) string {
+	prompt := promptui.Prompt{
+		Label:    label,
+		Default:  defaultValue,
+		Validate: validate,
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		userError.LogErrorf("prompt failed: %s", err)
+		os.Exit(1)
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/typescript"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+// mustBundle bundles compiled entrypoints of every action from compiledDir with esbuild installed in sources.
+// Returns directory with bundles, published as logic instead of compiled files and node_modules.
+func mustBundle(actions *actionsModel.ProjectActions, projectSlug string, compiledDir string) string {
+	mustExistCompiledFiles(compiledDir, actions)
+	if !util.ExistFile(typescript.EsbuildPath(actions.Sources)) {
+		userError.LogErrorf(
+			"esbuild not installed",
+			userError.NewUserError(
+				errors.New("esbuild not installed"),
+				commands.Colorizer.Sprintf(
+					"Bundling with runtime_bundle requires %s. Add it to devDependencies in %s.",
+					commands.Colorizer.Bold(commands.Colorizer.Red(typescript.EsbuildPackage)),
+					commands.Colorizer.Bold(filepath.Join(actions.Sources, typescript.PackageJsonFile)),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+
+	// Bundles are kept out of sources, so they are never zipped or watched as sources
+	bundleDir := filepath.Join(os.TempDir(), "tenderly-actions", strings.ReplaceAll(projectSlug, "/", "-"), "bundle")
+	util.RemoveDirWithContent(bundleDir)
+
+	logrus.Info("\nBundling actions...")
+	cmd := typescript.BundleCommand(
+		actions.Sources,
+		bundleEntrypoints(actions, compiledDir),
+		compiledDir,
+		bundleDir,
+		actionsModel.RuntimeNodeTargets[actions.Runtime],
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err := cmd.Run()
+	if err != nil {
+		userError.LogErrorf(
+			"failed to bundle actions: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to run: %s.",
+					commands.Colorizer.Bold(commands.Colorizer.Red(typescript.CommandString(cmd))),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+
+	return bundleDir
+}
+
+// bundleEntrypoints returns compiled file of every action locator, each file once.
+func bundleEntrypoints(actions *actionsModel.ProjectActions, compiledDir string) []string {
+	unique := make(map[string]bool)
+	for _, spec := range actions.Specs {
+		internalLocator, err := actionsModel.NewInternalLocator(spec.Function)
+		if err != nil {
+			// Locators are validated by mustExistCompiledFiles
+			continue
+		}
+		unique[filepath.Join(compiledDir, fmt.Sprintf("%s.js", internalLocator.Path))] = true
+	}
+
+	entrypoints := make([]string, 0, len(unique))
+	for entrypoint := range unique {
+		entrypoints = append(entrypoints, entrypoint)
+	}
+	sort.Strings(entrypoints)
+	return entrypoints
+}
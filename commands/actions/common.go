@@ -3,9 +3,12 @@ package actions
 import (
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/manifoldco/promptui"
+	"github.com/mattn/go-isatty"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/tenderly/tenderly-cli/commands"
@@ -15,15 +18,25 @@ import (
 	"github.com/tenderly/tenderly-cli/model"
 	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
 	"github.com/tenderly/tenderly-cli/rest"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
 	"github.com/tenderly/tenderly-cli/typescript"
 	"github.com/tenderly/tenderly-cli/userError"
 	"gopkg.in/yaml.v3"
 )
 
 var actionsProjectName string
+var packageManagerName string
 
 func init() {
 	actionsCmd.PersistentFlags().StringVar(&actionsProjectName, "project", "", "The project slug in which the actions will published & deployed")
+	actionsCmd.PersistentFlags().StringVar(
+		&packageManagerName, "package-manager", "",
+		fmt.Sprintf(
+			"Package manager used to install dependencies and build sources, one of {%s}. "+
+				"If not provided, it is detected from lock file in sources directory or its parents.",
+			strings.Join(typescript.PackageManagerNames(), ","),
+		),
+	)
 
 	commands.RootCmd.AddCommand(actionsCmd)
 }
@@ -116,6 +129,72 @@ func chooseProject(rest *rest.Rest, accountID string, createNewOption bool, only
 	return projectSlug
 }
 
+// chooseConfiguredProject picks one of the projects configured in tenderly.yaml from projects available to the user.
+func chooseConfiguredProject(rest *rest.Rest, allActions map[string]actionsModel.ProjectActions) string {
+	var slugs []string
+	for k := range allActions {
+		slugs = append(slugs, k)
+	}
+
+	accountID := config.GetString(config.AccountID)
+	return chooseProject(rest, accountID, false, slugs)
+}
+
+// mustInitRemoteProject logs in and picks project for commands that work only with the Tenderly API.
+// Sets r, actions and projectSlug, which is project of environment selected with --env if it overrides it.
+func mustInitRemoteProject() {
+	commands.CheckLogin()
+	r = commands.NewRest()
+
+	allActions := MustGetActions()
+	projectSlug = chooseConfiguredProject(r, allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+	mustApplyEnvironment(actions)
+	projectSlug = environmentProjectSlug()
+}
+
+// isInputTerminal reports whether standard input is a terminal, so the user can be prompted.
+func isInputTerminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) || isatty.IsCygwinTerminal(os.Stdin.Fd())
+}
+
+// chooseLocalProject picks the project from the --project flag or from projects configured in tenderly.yaml,
+// without calling the Tenderly API. Used by commands which must work offline.
+func chooseLocalProject(allActions map[string]actionsModel.ProjectActions) string {
+	var slugs []string
+	for slug := range allActions {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+
+	if actionsProjectName != "" {
+		for _, slug := range slugs {
+			if strings.EqualFold(slug, actionsProjectName) ||
+				strings.HasSuffix(strings.ToLower(slug), "/"+strings.ToLower(actionsProjectName)) {
+				return slug
+			}
+		}
+		return actionsProjectName
+	}
+
+	if len(slugs) == 1 {
+		return slugs[0]
+	}
+
+	promptProjects := promptui.Select{
+		Label: "Select Project",
+		Items: slugs,
+	}
+
+	_, result, err := promptProjects.Run()
+	if err != nil {
+		userError.LogErrorf("prompt project failed: %s", err)
+		os.Exit(1)
+	}
+
+	return result
+}
+
 type actionsTenderlyYaml struct {
 	Actions map[string]actionsModel.ProjectActions `yaml:"actions"`
 }
@@ -140,8 +219,7 @@ func MustGetActions() map[string]actionsModel.ProjectActions {
 		os.Exit(1)
 	}
 
-	var tenderlyYaml actionsTenderlyYaml
-	err = yaml.Unmarshal(content, &tenderlyYaml)
+	allActions, err := parseActions(content)
 	if err != nil {
 		userError.LogErrorf("failed unmarshalling actions config: %s",
 			userError.NewUserError(
@@ -152,7 +230,48 @@ func MustGetActions() map[string]actionsModel.ProjectActions {
 		os.Exit(1)
 	}
 
-	return tenderlyYaml.Actions
+	return allActions
+}
+
+func configFileName() string {
+	return filepath.Join(config.ProjectDirectory, fmt.Sprintf("%s.yaml", config.ProjectConfigName))
+}
+
+// configNodes returns positions of nodes in tenderly.yaml. Empty if it can't be read, positions are best effort.
+func configNodes() actionsModel.NodeMap {
+	content, err := config.ReadProjectConfig()
+	if err != nil {
+		logrus.Debugf("failed reading project config for positions: %s", err)
+		return nil
+	}
+	nodes, err := actionsModel.NewNodeMap(configFileName(), content)
+	if err != nil {
+		logrus.Debugf("failed parsing project config for positions: %s", err)
+		return nil
+	}
+	return nodes
+}
+
+// specNodes returns positions of nodes in tenderly.yaml relative to specs of the project, so they can be found
+// by ValidatorContext used when validating a single spec, e.g. myAction.trigger.
+func specNodes(projectSlug string) actionsModel.NodeMap {
+	nodes := configNodes()
+	for _, slug := range []string{projectSlug, strings.ToLower(projectSlug)} {
+		specs := nodes.Sub(actionsModel.ValidatorContext(config.Actions).With(slug).With("specs"))
+		if len(specs) > 0 {
+			return specs
+		}
+	}
+	return nil
+}
+
+func parseActions(content []byte) (map[string]actionsModel.ProjectActions, error) {
+	var tenderlyYaml actionsTenderlyYaml
+	err := yaml.Unmarshal(content, &tenderlyYaml)
+	if err != nil {
+		return nil, err
+	}
+	return tenderlyYaml.Actions, nil
 }
 
 func mustGetProjectActions(actions map[string]actionsModel.ProjectActions, projectSlug string) *actionsModel.ProjectActions {
@@ -172,6 +291,54 @@ func mustGetProjectActions(actions map[string]actionsModel.ProjectActions, proje
 	return &ret
 }
 
+func mustGetActionSpec(actions *actionsModel.ProjectActions, projectSlug string, actionName string) *actionsModel.ActionSpec {
+	spec, exists := actions.Specs[actionName]
+	if !exists {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"Action %s not found in project %s.",
+			commands.Colorizer.Bold(commands.Colorizer.Red(actionName)),
+			commands.Colorizer.Bold(projectSlug),
+		))
+		os.Exit(1)
+	}
+	return spec
+}
+
+// mustGetRemoteAction finds deployed or published action by name.
+func mustGetRemoteAction(rest *rest.Rest, projectSlug string, actionName string) generatedActions.Action {
+	for _, action := range mustGetRemoteActions(rest, projectSlug) {
+		if action.Name == actionName {
+			return action
+		}
+	}
+
+	logrus.Error(commands.Colorizer.Sprintf(
+		"Action %s is not published in project %s.",
+		commands.Colorizer.Bold(commands.Colorizer.Red(actionName)),
+		commands.Colorizer.Bold(projectSlug),
+	))
+	os.Exit(1)
+	return generatedActions.Action{}
+}
+
+func mustGetRemoteActions(rest *rest.Rest, projectSlug string) []generatedActions.Action {
+	response, err := rest.Actions.GetActions(projectSlug)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to get actions: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to get actions for project %s.",
+					commands.Colorizer.Bold(commands.Colorizer.Red(projectSlug)),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	return response.Actions
+}
+
 func mustValidateDependencies(packageJSON *typescript.PackageJson, validator *packagejson.Validator) (*packagejson.ValidationResult, error) {
 	depResult, err := validator.Validate(packageJSON.Dependencies)
 	if err != nil {
@@ -199,19 +366,20 @@ func mustInstallDependencies(sourcesDir string) {
 	if len(packageJSON.Dependencies)+len(packageJSON.DevDependencies) == 0 {
 		return
 	}
-	logrus.Info("\nInstalling dependencies...")
+	packageManager := mustGetPackageManager(sourcesDir)
+	logrus.Infof("\nInstalling dependencies with %s...", packageManager.Name)
 
-	cmd := exec.Command("npm", "--prefix", sourcesDir, "install", "--verbose")
+	cmd := packageManager.InstallCommand(sourcesDir)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	err := cmd.Start()
 	if err != nil {
-		userError.LogErrorf("failed to run npm install dependencies: %s",
+		userError.LogErrorf("failed to run install dependencies: %s",
 			userError.NewUserError(err,
 				commands.Colorizer.Sprintf(
 					"Failed to run: %s.",
-					commands.Colorizer.Bold(commands.Colorizer.Red(fmt.Sprintf("npm --prefix %s install", sourcesDir))),
+					commands.Colorizer.Bold(commands.Colorizer.Red(typescript.CommandString(cmd))),
 				),
 			),
 		)
@@ -220,14 +388,31 @@ func mustInstallDependencies(sourcesDir string) {
 
 	err = cmd.Wait()
 	if err != nil {
-		userError.LogErrorf("failed to finish npm install dependencies.",
+		userError.LogErrorf("failed to finish install dependencies.",
 			userError.NewUserError(err,
 				commands.Colorizer.Sprintf(
 					"Failed to run: %s.",
-					commands.Colorizer.Bold(commands.Colorizer.Red(fmt.Sprintf("npm --prefix %s install", sourcesDir))),
+					commands.Colorizer.Bold(commands.Colorizer.Red(typescript.CommandString(cmd))),
 				),
 			),
 		)
 		os.Exit(1)
 	}
 }
+
+// mustGetPackageManager returns package manager set with --package-manager, or detected one.
+func mustGetPackageManager(sourcesDir string) typescript.PackageManager {
+	if packageManagerName == "" {
+		return typescript.DetectPackageManager(sourcesDir)
+	}
+
+	packageManager, err := typescript.GetPackageManager(packageManagerName)
+	if err != nil {
+		userError.LogErrorf(
+			"invalid package manager: %s",
+			userError.NewUserError(err, commands.Colorizer.Sprintf("%s.", commands.Colorizer.Red(err.Error()))),
+		)
+		os.Exit(1)
+	}
+	return packageManager
+}
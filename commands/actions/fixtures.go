@@ -0,0 +1,196 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+var fixturesDir string
+var fixturesLast int
+var fixturesCompareLogs bool
+
+func init() {
+	fixturesCmd.PersistentFlags().StringVar(
+		&fixturesDir, "dir", actionsModel.FixturesDir,
+		"Directory with fixtures, every action has its own subdirectory.",
+	)
+	fixturesPullCmd.PersistentFlags().IntVar(&fixturesLast, "last", 10, "Number of most recent executions to pull.")
+	fixturesReplayCmd.PersistentFlags().BoolVar(
+		&fixturesCompareLogs, "compare-logs", false,
+		"Compare logs of local execution with recorded logs, line by line.",
+	)
+
+	fixturesCmd.AddCommand(fixturesPullCmd)
+	fixturesCmd.AddCommand(fixturesReplayCmd)
+	actionsCmd.AddCommand(fixturesCmd)
+}
+
+var fixturesCmd = &cobra.Command{
+	Use:   "fixtures",
+	Short: "Record executions of deployed action and replay them locally",
+	Long: "Fixture is a recorded execution of deployed action: the event it received, " +
+		"whether it succeeded, its error and logs. Replaying fixtures runs the action locally with recorded events " +
+		"and reports executions which ended differently, so real traffic can be used as regression tests.",
+}
+
+var fixturesPullCmd = &cobra.Command{
+	Use:   "pull <action-name>",
+	Short: "Download recent executions of deployed action as fixtures",
+	Args:  cobra.ExactArgs(1),
+	Run:   fixturesPullFunc,
+}
+
+var fixturesReplayCmd = &cobra.Command{
+	Use:   "replay <action-name>",
+	Short: "Run action locally with recorded events and compare outcomes",
+	Long: "Builds actions and runs the action locally with the event of every fixture. " +
+		"Exits with non-zero code if any execution ends differently than recorded.",
+	Args: cobra.ExactArgs(1),
+	Run:  fixturesReplayFunc,
+}
+
+func fixturesPullFunc(cmd *cobra.Command, args []string) {
+	actionName := args[0]
+
+	mustInitRemoteProject()
+	action := mustGetRemoteAction(r, projectSlug, actionName)
+
+	response, err := r.Actions.GetCalls(projectSlug, action.Id, fixturesLast)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to get executions: %s",
+			userError.NewUserError(err, "Failed to get action executions."),
+		)
+		os.Exit(1)
+	}
+	calls := response.Calls
+	sort.Slice(calls, func(i, j int) bool {
+		return time.Time(calls[i].CreatedAt).After(time.Time(calls[j].CreatedAt))
+	})
+	if len(calls) > fixturesLast {
+		calls = calls[:fixturesLast]
+	}
+
+	dir := filepath.Join(fixturesDir, actionName)
+	pulled := 0
+	for _, summary := range calls {
+		status := summary.Status.Value()
+		if status != generatedActions.CallStatus_SUCCEEDED && status != generatedActions.CallStatus_FAILED {
+			logrus.Info(commands.Colorizer.Sprintf(
+				"Skipping execution %s, status %s.", commands.Colorizer.Bold(summary.Id), status,
+			))
+			continue
+		}
+		if util.ExistFile(filepath.Join(dir, actionsModel.FixtureFileName(time.Time(summary.CreatedAt), summary.Id))) {
+			continue
+		}
+
+		fixture, err := actionsModel.NewFixture(mustGetCall(r, projectSlug, action.Id, summary.Id))
+		if err == nil {
+			err = actionsModel.SaveFixture(dir, fixture)
+		}
+		if err != nil {
+			userError.LogErrorf(
+				"failed to save fixture: %s",
+				userError.NewUserError(
+					err,
+					commands.Colorizer.Sprintf(
+						"Failed to save execution %s as fixture: %s",
+						commands.Colorizer.Bold(summary.Id),
+						commands.Colorizer.Red(err.Error()),
+					),
+				),
+			)
+			os.Exit(1)
+		}
+		logrus.Info(commands.Colorizer.Sprintf("- %s", commands.Colorizer.Green(fixture.Path)))
+		pulled++
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf(
+		"\nPulled %d new fixtures of action %s into %s.",
+		pulled,
+		commands.Colorizer.Bold(actionName),
+		commands.Colorizer.Bold(dir),
+	))
+}
+
+func fixturesReplayFunc(cmd *cobra.Command, args []string) {
+	actionName := args[0]
+
+	allActions := MustGetActions()
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+	spec := mustGetActionSpec(actions, projectSlug, actionName)
+
+	dir := filepath.Join(fixturesDir, actionName)
+	fixtures, err := actionsModel.LoadFixtures(dir)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to load fixtures: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf("Failed to load fixtures: %s", commands.Colorizer.Red(err.Error())),
+			),
+		)
+		os.Exit(1)
+	}
+	if len(fixtures) == 0 {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"No fixtures found in %s. Run %s to pull recent executions.",
+			commands.Colorizer.Bold(commands.Colorizer.Red(dir)),
+			commands.Colorizer.Bold(commands.Colorizer.Green("tenderly actions fixtures pull "+actionName)),
+		))
+		os.Exit(1)
+	}
+
+	mustBuildLocal(actions, loadBuildContractABIs(actions))
+
+	failed := 0
+	for _, fixture := range fixtures {
+		logrus.Info(commands.Colorizer.Sprintf("\nReplaying %s...\n", commands.Colorizer.Bold(fixture.Path)))
+
+		result := mustRunLocal(spec, actionsModel.NewExecutionPayload(fixture.Payload, nil), "")
+		outcome := actionsModel.FixtureOutcome{Success: result.Success}
+		if result.Error != nil {
+			outcome.Error = &actionsModel.FixtureError{Name: result.Error.Name, Message: result.Error.Message}
+		}
+		for _, line := range result.Logs {
+			outcome.Logs = append(outcome.Logs, line.Message)
+		}
+
+		differences := fixture.Compare(outcome, fixturesCompareLogs)
+		if len(differences) == 0 {
+			logrus.Info(commands.Colorizer.Green("\nSame as recorded."))
+			continue
+		}
+		failed++
+		logrus.Info(commands.Colorizer.Red("\nDifferent than recorded:"))
+		for _, difference := range differences {
+			logrus.Info(commands.Colorizer.Sprintf("  %s", commands.Colorizer.Red(difference)))
+		}
+	}
+
+	if failed > 0 {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"\n%d of %d fixtures of action %s ended differently than recorded.",
+			failed, len(fixtures), commands.Colorizer.Bold(actionName),
+		))
+		os.Exit(1)
+	}
+	logrus.Info(commands.Colorizer.Green(
+		fmt.Sprintf("\nAll %d fixtures of action %s ended as recorded.", len(fixtures), actionName),
+	))
+}
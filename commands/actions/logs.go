@@ -0,0 +1,198 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/rest"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+const logsFollowInterval = 5 * time.Second
+
+var logsFollow bool
+var logsSince string
+var logsLimit int
+
+func init() {
+	logsCmd.PersistentFlags().BoolVarP(&logsFollow, "follow", "f", false, "Keep polling for new executions.")
+	logsCmd.PersistentFlags().StringVar(
+		&logsSince, "since", "",
+		"Show executions newer than relative duration (e.g. 30m, 2h) or timestamp (e.g. 2006-01-02T15:04:05Z).",
+	)
+	logsCmd.PersistentFlags().IntVar(&logsLimit, "limit", 20, "Maximum number of executions to fetch.")
+
+	actionsCmd.AddCommand(logsCmd)
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs <action-name>",
+	Short: "Show execution logs of deployed action",
+	Long: "Shows execution history of deployed action: status, duration, logs and errors of each execution. " +
+		"With --output json every execution is printed as a single JSON line.",
+	Args: cobra.ExactArgs(1),
+	Run:  logsFunc,
+}
+
+func logsFunc(cmd *cobra.Command, args []string) {
+	actionName := args[0]
+
+	var since *time.Time
+	if logsSince != "" {
+		parsed, err := parseSince(logsSince, time.Now())
+		if err != nil {
+			userError.LogErrorf(
+				"invalid since: %s",
+				userError.NewUserError(
+					err,
+					commands.Colorizer.Sprintf(
+						"Invalid value %s for --since. Use duration like %s or timestamp like %s.",
+						commands.Colorizer.Bold(commands.Colorizer.Red(logsSince)),
+						commands.Colorizer.Bold("2h"),
+						commands.Colorizer.Bold("2006-01-02T15:04:05Z"),
+					),
+				),
+			)
+			os.Exit(1)
+		}
+		since = &parsed
+	}
+
+	mustInitRemoteProject()
+	action := mustGetRemoteAction(r, projectSlug, actionName)
+
+	printed := make(map[string]bool)
+	printCalls := func() {
+		for _, summary := range mustGetCalls(r, projectSlug, action.Id, since) {
+			if printed[summary.Id] {
+				continue
+			}
+			// Pending executions are printed once they finish, so their logs are complete
+			finished := summary.Status.Value() != generatedActions.CallStatus_SUBMITTED
+			if logsFollow && !finished {
+				continue
+			}
+			printCall(mustGetCall(r, projectSlug, action.Id, summary.Id))
+			if finished {
+				printed[summary.Id] = true
+			}
+		}
+	}
+
+	if !commands.IsJSONOutput() {
+		logrus.Info(commands.Colorizer.Sprintf("Executions of action %s:\n", commands.Colorizer.Bold(actionName)))
+	}
+	printCalls()
+	if !logsFollow {
+		return
+	}
+
+	for {
+		time.Sleep(logsFollowInterval)
+		printCalls()
+	}
+}
+
+// parseSince accepts duration relative to now or RFC3339 timestamp.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return now.Add(-duration), nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return timestamp, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// mustGetCalls returns executions newer than since, oldest first.
+func mustGetCalls(r *rest.Rest, projectSlug string, actionID string, since *time.Time) []generatedActions.CallSummary {
+	response, err := r.Actions.GetCalls(projectSlug, actionID, logsLimit)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to get executions: %s",
+			userError.NewUserError(err, "Failed to get action executions."),
+		)
+		os.Exit(1)
+	}
+
+	var calls []generatedActions.CallSummary
+	for _, call := range response.Calls {
+		if since != nil && time.Time(call.CreatedAt).Before(*since) {
+			continue
+		}
+		calls = append(calls, call)
+	}
+	sort.Slice(calls, func(i, j int) bool {
+		return time.Time(calls[i].CreatedAt).Before(time.Time(calls[j].CreatedAt))
+	})
+	return calls
+}
+
+func mustGetCall(r *rest.Rest, projectSlug string, actionID string, callID string) generatedActions.Call {
+	response, err := r.Actions.GetCall(projectSlug, actionID, callID)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to get execution: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf("Failed to get execution %s.", commands.Colorizer.Bold(callID)),
+			),
+		)
+		os.Exit(1)
+	}
+	return response.Call
+}
+
+func printCall(call generatedActions.Call) {
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(call)
+		return
+	}
+
+	duration := "-"
+	if call.Time != nil {
+		duration = fmt.Sprintf("%dms", *call.Time)
+	}
+	logrus.Info(commands.Colorizer.Sprintf(
+		"%s  %s  %s  %s",
+		time.Time(call.CreatedAt).Format(time.RFC3339),
+		colorizeCallStatus(call.Status),
+		duration,
+		commands.Colorizer.Faint(call.Id),
+	))
+
+	if call.ParsedLogs != nil {
+		for _, line := range call.ParsedLogs.Lines {
+			logrus.Info(commands.Colorizer.Sprintf("  [%s] %s", line.Severity, line.Message))
+		}
+	}
+	if call.ParsedError != nil {
+		logrus.Info(commands.Colorizer.Sprintf(
+			"  %s: %s",
+			commands.Colorizer.Red(call.ParsedError.Name),
+			commands.Colorizer.Red(call.ParsedError.Message),
+		))
+		if call.ParsedError.Stacktrace != "" {
+			logrus.Info(commands.Colorizer.Faint(call.ParsedError.Stacktrace))
+		}
+	}
+}
+
+func colorizeCallStatus(status generatedActions.CallStatus) string {
+	switch status.Value() {
+	case generatedActions.CallStatus_SUCCEEDED:
+		return commands.Colorizer.Green(status.String()).String()
+	case generatedActions.CallStatus_FAILED:
+		return commands.Colorizer.Red(status.String()).String()
+	}
+	return commands.Colorizer.Yellow(status.String()).String()
+}
@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+var matchTxFile string
+
+func init() {
+	matchCmd.PersistentFlags().StringVar(
+		&matchTxFile, "tx", "",
+		"Path to the JSON transaction payload. Optional \"status\" field is used for status filter.",
+	)
+	_ = matchCmd.MarkPersistentFlagRequired("tx")
+
+	actionsCmd.AddCommand(matchCmd)
+}
+
+var matchCmd = &cobra.Command{
+	Use:   "match <action-name>",
+	Short: "Match transaction against action trigger",
+	Long: "Evaluates transaction trigger of the action against recorded transaction, without deploying. " +
+		"Reports which filter matched and which part of the filter rejected the transaction. " +
+		"Function and event names are resolved and parameters decoded with ABIs of contracts pushed to project, " +
+		"if logged in, and in build directory of configured provider. " +
+		"Conditions that need data not present in the payload are reported as unknown: stateChanged and ethBalance " +
+		"are never evaluated, internal calls need transaction trace, names and parameters need contract ABI.",
+	Args: cobra.ExactArgs(1),
+	Run:  matchFunc,
+}
+
+func matchFunc(cmd *cobra.Command, args []string) {
+	actionName := args[0]
+
+	allActions := MustGetActions()
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+	spec := mustGetActionSpec(actions, projectSlug, actionName)
+
+	abis := loadBuildContractABIs(actions)
+	mustParseAndValidateActions(actions, abis)
+
+	if spec.TriggerParsed.Type != actionsModel.TransactionType {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"Action %s has %s trigger, only %s triggers can be matched.",
+			commands.Colorizer.Bold(commands.Colorizer.Red(actionName)),
+			commands.Colorizer.Bold(spec.TriggerParsed.Type),
+			commands.Colorizer.Bold(actionsModel.TransactionType),
+		))
+		os.Exit(1)
+	}
+
+	tx, err := actionsModel.ParseRecordedTransaction([]byte(util.ReadFile(matchTxFile)))
+	if err != nil {
+		userError.LogErrorf(
+			"failed to parse transaction: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to parse transaction %s: %s",
+					commands.Colorizer.Bold(commands.Colorizer.Red(matchTxFile)),
+					err.Error(),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+
+	result := spec.TriggerParsed.Transaction.Match(tx, abis)
+
+	logrus.Info(commands.Colorizer.Sprintf("\nMatching transaction %s against action %s:",
+		commands.Colorizer.Bold(tx.Payload.Hash),
+		commands.Colorizer.Bold(actionName),
+	))
+	for _, filter := range result.Filters {
+		logrus.Info(commands.Colorizer.Sprintf("\nFilter %d: %s", filter.Index, colorizeOutcome(filter.Outcome)))
+		for _, sub := range filter.SubFilters {
+			logrus.Info(commands.Colorizer.Sprintf("  %s %s: %s",
+				colorizeOutcome(sub.Outcome),
+				commands.Colorizer.Bold(sub.Field),
+				sub.Reason,
+			))
+		}
+	}
+
+	switch result.Outcome {
+	case actionsModel.MatchMatched:
+		logrus.Info(commands.Colorizer.Green("\nTransaction matches the trigger."))
+	case actionsModel.MatchUnknown:
+		logrus.Info(commands.Colorizer.Yellow(
+			"\nTransaction may match the trigger, some filters can't be evaluated offline.",
+		))
+	default:
+		logrus.Error(commands.Colorizer.Red("\nTransaction doesn't match the trigger."))
+		os.Exit(1)
+	}
+}
+
+func colorizeOutcome(outcome actionsModel.MatchOutcome) string {
+	switch outcome {
+	case actionsModel.MatchMatched:
+		return commands.Colorizer.Green(string(outcome)).String()
+	case actionsModel.MatchRejected:
+		return commands.Colorizer.Red(string(outcome)).String()
+	}
+	return commands.Colorizer.Yellow(string(outcome)).String()
+}
@@ -0,0 +1,205 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	"github.com/tenderly/tenderly-cli/typescript"
+	"github.com/tenderly/tenderly-cli/userError"
+	"github.com/tenderly/tenderly-cli/zip"
+)
+
+var packageAnalyze bool
+var packageTop int
+var packageManifest bool
+
+func init() {
+	packageCmd.PersistentFlags().BoolVar(
+		&packageAnalyze, "analyze", false,
+		"List the largest files and packages going into logic and dependencies zips.",
+	)
+	packageCmd.PersistentFlags().IntVar(
+		&packageTop, "top", 10, "Number of the largest files and packages listed with --analyze.",
+	)
+	packageCmd.PersistentFlags().BoolVar(
+		&packageManifest, "manifest", false,
+		"Print SHA-256 of every file in zips, the version of each zip is SHA-256 of this list. "+
+			"The list is not published, it describes only the local build.",
+	)
+
+	actionsCmd.AddCommand(packageCmd)
+}
+
+var packageCmd = &cobra.Command{
+	Use:   "package",
+	Short: "Package actions without publishing",
+	Long: "Builds actions locally and zips sources and dependencies the same way publish does, then prints size of " +
+		"every zip compared to the limit. Files matching patterns in .tenderlyignore in sources directory " +
+		"(gitignore syntax) are left out. Exits with non-zero code if any zip exceeds the limit.",
+	Args: cobra.NoArgs,
+	Run:  packageFunc,
+}
+
+type packageLayer struct {
+	Name       string        `json:"name"`
+	Dir        string        `json:"dir"`
+	LimitBytes int           `json:"limitBytes"`
+	Files      int           `json:"files"`
+	Version    string        `json:"version"`
+	Manifest   *string       `json:"manifest,omitempty"`
+	Analysis   *zip.Analysis `json:"analysis"`
+	insidePath string
+}
+
+func packageFunc(cmd *cobra.Command, args []string) {
+	allActions := MustGetActions()
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+	mustBuildLocal(actions, loadBuildContractABIs(actions))
+
+	layers := []*packageLayer{{Name: "logic", Dir: outDir, insidePath: srcPathInZip}}
+	dependenciesDir := filepath.Join(actions.Sources, typescript.NodeModulesDir)
+	if !actions.RuntimeBundle && util.ExistDir(dependenciesDir) {
+		layers = append(layers, &packageLayer{Name: "dependencies", Dir: dependenciesDir, insidePath: nodeModulesPathInZip})
+	}
+
+	ignore := mustLoadIgnore(actions.Sources)
+	exceeded := false
+	for _, layer := range layers {
+		layer.LimitBytes = zipLimitBytes
+		var manifest *zip.Manifest
+		layer.Analysis, manifest = mustAnalyzeLayer(layer, ignore)
+		layer.Files = len(layer.Analysis.Files)
+		layer.Version = manifest.Hash()
+		if packageManifest {
+			layer.Manifest = manifest.Text()
+		}
+		if layer.Analysis.Bytes > layer.LimitBytes {
+			exceeded = true
+		}
+
+		// node_modules easily have tens of thousands of files, only the largest ones are listed
+		if packageAnalyze {
+			layer.Analysis.Files = top(layer.Analysis.Files, packageTop)
+			layer.Analysis.Packages = top(layer.Analysis.Packages, packageTop)
+		} else {
+			layer.Analysis.Files = nil
+			layer.Analysis.Packages = nil
+		}
+	}
+
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(layers)
+	} else {
+		for _, layer := range layers {
+			printPackageLayer(layer)
+		}
+	}
+
+	if exceeded {
+		if !commands.IsJSONOutput() {
+			logrus.Error(commands.Colorizer.Red(fmt.Sprintf(
+				"\nZip exceeds the maximum size limit. Exclude files not needed at runtime with %s.", zip.IgnoreFile,
+			)))
+		}
+		os.Exit(1)
+	}
+}
+
+func mustAnalyzeLayer(layer *packageLayer, ignore *zip.Ignore) (*zip.Analysis, *zip.Manifest) {
+	analysis, manifest, err := analyzeLayer(layer, ignore)
+	if err != nil {
+		userError.LogErrorf(
+			"package failed: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed packaging %s: %s",
+					commands.Colorizer.Bold(layer.Dir),
+					commands.Colorizer.Red(err.Error()),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	return analysis, manifest
+}
+
+func analyzeLayer(layer *packageLayer, ignore *zip.Ignore) (*zip.Analysis, *zip.Manifest, error) {
+	_, content, err := zip.Zip(layer.Dir, layer.insidePath, ignore)
+	if err != nil {
+		return nil, nil, err
+	}
+	analysis, err := zip.Analyze(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	manifest, err := zip.NewManifest(content)
+	if err != nil {
+		return nil, nil, err
+	}
+	return analysis, manifest, nil
+}
+
+func printPackageLayer(layer *packageLayer) {
+	size := formatBytes(uint64(layer.Analysis.Bytes))
+	if layer.Analysis.Bytes > layer.LimitBytes {
+		size = commands.Colorizer.Red(size).String()
+	} else {
+		size = commands.Colorizer.Green(size).String()
+	}
+	logrus.Info(commands.Colorizer.Sprintf(
+		"\n%s zip (%s): %s of %s limit, %d files",
+		commands.Colorizer.Bold(layer.Name),
+		layer.Dir,
+		size,
+		formatBytes(uint64(layer.LimitBytes)),
+		layer.Files,
+	))
+	logrus.Infof("  Version: %s", layer.Version)
+	if layer.Manifest != nil {
+		logrus.Info("  Manifest:")
+		for _, line := range strings.Split(strings.TrimSuffix(*layer.Manifest, "\n"), "\n") {
+			logrus.Infof("    %s", line)
+		}
+	}
+	if !packageAnalyze {
+		return
+	}
+
+	if len(layer.Analysis.Packages) > 0 {
+		logrus.Info("  Largest packages:")
+		for _, pkg := range layer.Analysis.Packages {
+			logrus.Infof("  %10s  %s (%d files)", formatBytes(pkg.CompressedBytes), pkg.Name, pkg.Files)
+		}
+	}
+	logrus.Info("  Largest files:")
+	for _, file := range layer.Analysis.Files {
+		logrus.Infof("  %10s  %s", formatBytes(file.CompressedBytes), file.Name)
+	}
+}
+
+func top(sizes []zip.Size, n int) []zip.Size {
+	if n >= 0 && len(sizes) > n {
+		return sizes[:n]
+	}
+	return sizes
+}
+
+func formatBytes(bytes uint64) string {
+	switch {
+	case bytes >= 1024*1024:
+		return fmt.Sprintf("%.1fMB", float64(bytes)/1024/1024)
+	case bytes >= 1024:
+		return fmt.Sprintf("%.1fKB", float64(bytes)/1024)
+	default:
+		return fmt.Sprintf("%dB", bytes)
+	}
+}
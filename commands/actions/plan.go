@@ -0,0 +1,125 @@
+package actions
+
+import (
+	"os"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+var deployYes bool
+
+func init() {
+	deployCmd.PersistentFlags().BoolVarP(&deployYes, "yes", "y", false, "Deploy without confirming the plan.")
+
+	actionsCmd.AddCommand(planCmd)
+}
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show what deploy would change",
+	Long: "Builds actions and compares them with currently deployed versions: " +
+		"added, removed and changed actions, actions which are only published and whether logic or dependencies " +
+		"would be uploaded.",
+	Run: planFunc,
+}
+
+func planFunc(cmd *cobra.Command, args []string) {
+	buildFunc(cmd, args)
+	plan := mustGetPlan()
+
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(plan)
+		return
+	}
+	printPlan(plan)
+}
+
+// mustGetPlan compares built actions with published actions. Must be called after buildFunc.
+func mustGetPlan() actionsModel.Plan {
+	remote := mustGetRemoteActions(r, projectSlug)
+	if isActionFilterSet() {
+		// Skipped actions are left untouched, they shouldn't show up as removed
+		selected := remote[:0]
+		for _, action := range remote {
+			if _, exists := actions.Specs[action.Name]; exists {
+				selected = append(selected, action)
+			}
+		}
+		remote = selected
+	}
+	return actionsModel.NewPlan(actions.ToRequest(sources), remote, logicExist, dependenciesExist)
+}
+
+func printPlan(plan actionsModel.Plan) {
+	logrus.Info(commands.Colorizer.Sprintf("\nPlan for project %s:", commands.Colorizer.Bold(projectSlug)))
+	for _, action := range plan.Actions {
+		switch action.Kind {
+		case actionsModel.PlanAdd:
+			logrus.Info(commands.Colorizer.Green("  + " + action.Name))
+		case actionsModel.PlanUpdate:
+			logrus.Info(commands.Colorizer.Sprintf(
+				"  %s (%s)",
+				commands.Colorizer.Yellow("~ "+action.Name),
+				strings.Join(action.Changes, ", "),
+			))
+		case actionsModel.PlanRemove:
+			logrus.Info(commands.Colorizer.Sprintf(
+				"  %s (not in tenderly.yaml, stays published)",
+				commands.Colorizer.Red("- "+action.Name),
+			))
+		default:
+			logrus.Info(commands.Colorizer.Faint("    " + action.Name))
+		}
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf("\n  logic: %s", describeLayerChange(plan.LogicChanged)))
+	logrus.Info(commands.Colorizer.Sprintf("  dependencies: %s", describeLayerChange(plan.DependenciesChanged)))
+
+	logrus.Info(commands.Colorizer.Sprintf(
+		"\nPlan: %d to add, %d to change, %d unchanged, %d not in tenderly.yaml.",
+		plan.Count(actionsModel.PlanAdd),
+		plan.Count(actionsModel.PlanUpdate),
+		plan.Count(actionsModel.PlanUnchanged),
+		plan.Count(actionsModel.PlanRemove),
+	))
+}
+
+func describeLayerChange(changed bool) string {
+	if changed {
+		return commands.Colorizer.Yellow("changed, will be uploaded").String()
+	}
+	return commands.Colorizer.Faint("unchanged").String()
+}
+
+func mustConfirmDeploy() {
+	prompt := promptui.Prompt{
+		Label:     "Deploy these changes",
+		IsConfirm: true,
+	}
+
+	_, err := prompt.Run()
+	if err == promptui.ErrAbort {
+		logrus.Info("Deploy cancelled.")
+		os.Exit(1)
+	}
+	if err != nil {
+		userError.LogErrorf(
+			"prompt deploy failed: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Couldn't confirm deploy. Use %s to deploy without confirmation.",
+					commands.Colorizer.Bold("--yes"),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+}
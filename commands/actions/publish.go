@@ -3,7 +3,6 @@ package actions
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
@@ -16,12 +15,13 @@ import (
 	"github.com/tenderly/tenderly-cli/commands"
 	"github.com/tenderly/tenderly-cli/commands/util"
 	"github.com/tenderly/tenderly-cli/commands/util/packagejson"
-	"github.com/tenderly/tenderly-cli/config"
 	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
 	"github.com/tenderly/tenderly-cli/rest"
+	"github.com/tenderly/tenderly-cli/rest/payloads"
 	conjureactions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
 	"github.com/tenderly/tenderly-cli/typescript"
 	"github.com/tenderly/tenderly-cli/userError"
+	"github.com/tenderly/tenderly-cli/zip"
 )
 
 var (
@@ -44,7 +44,36 @@ var (
 	dependenciesExist bool
 )
 
+var onlyActions []string
+var excludeActions []string
+var environmentName string
+
+// Environment selected with --env, nil if not set
+var environment *actionsModel.Environment
+
 func init() {
+	for _, cmd := range []*cobra.Command{buildCmd, publishCmd, deployCmd, planCmd} {
+		cmd.PersistentFlags().StringSliceVar(
+			&onlyActions, "only", nil, "Comma separated names of actions to build. Other actions are skipped.",
+		)
+		cmd.PersistentFlags().StringSliceVar(
+			&excludeActions, "exclude", nil, "Comma separated names of actions to skip.",
+		)
+		cmd.PersistentFlags().StringVar(
+			&environmentName, "env", "",
+			"Environment from environments section of actions config, whose overrides are applied to actions.",
+		)
+	}
+	// Commands working with published actions only need project of environment
+	for _, cmd := range []*cobra.Command{
+		logsCmd, stopCmd, resumeCmd, versionsCmd, rollbackCmd, secretsCmd, storageCmd, fixturesPullCmd,
+	} {
+		cmd.PersistentFlags().StringVar(
+			&environmentName, "env", "",
+			"Environment from environments section of actions config, whose project is used.",
+		)
+	}
+
 	actionsCmd.AddCommand(buildCmd)
 	actionsCmd.AddCommand(publishCmd)
 	actionsCmd.AddCommand(deployCmd)
@@ -53,8 +82,10 @@ func init() {
 var buildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Build actions for project",
-	Long:  "If you just want to validate configuration or build implementation without deploying.",
-	Run:   buildFunc,
+	Long: "If you just want to validate configuration or build implementation without deploying. " +
+		"Typescript actions get event types narrowed by their triggers, generated into " +
+		actionsModel.TypesDir + " directory of sources before compiling. Types of removed actions are deleted.",
+	Run: buildFunc,
 }
 
 var publishCmd = &cobra.Command{
@@ -72,19 +103,126 @@ var deployCmd = &cobra.Command{
 }
 
 func buildFunc(cmd *cobra.Command, args []string) {
+	if buildWatch {
+		watchFunc()
+		return
+	}
+	if buildLocalOnly {
+		buildLocalFunc()
+		return
+	}
+
 	commands.CheckLogin()
 	r = commands.NewRest()
 
 	allActions := MustGetActions()
-	var slugs []string
-	for k := range allActions {
-		slugs = append(slugs, k)
+	projectSlug = chooseConfiguredProject(r, allActions)
+
+	actions = mustGetProjectActions(allActions, projectSlug)
+	mustApplyEnvironment(actions)
+	mustFilterActions(actions)
+	mustBuildLocal(actions, loadContractABIs(r, environmentProjectSlug(), actions))
+
+	// Positions in config are looked up by configured project, so it is replaced only after local build
+	projectSlug = environmentProjectSlug()
+	sources = mustValidateAndGetSources(r, actions, projectSlug, sourcesDir)
+	logrus.Info(commands.Colorizer.Green("\nBuild completed."))
+}
+
+// environmentProjectSlug returns project of environment selected with --env, or configured project if not overridden.
+func environmentProjectSlug() string {
+	if environment != nil && environment.Project != nil {
+		return *environment.Project
 	}
+	return projectSlug
+}
 
-	accountID := config.GetString(config.AccountID)
-	projectSlug = chooseProject(r, accountID, false, slugs)
+// mustApplyEnvironment merges overrides of environment selected with --env into specs. Sets environment.
+func mustApplyEnvironment(actions *actionsModel.ProjectActions) {
+	if environmentName == "" {
+		return
+	}
 
-	actions = mustGetProjectActions(allActions, projectSlug)
+	var err error
+	environment, err = actions.ApplyEnvironment(environmentName)
+	if err != nil {
+		userError.LogErrorf(
+			"invalid environment: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Invalid --env: %s. Check environments in tenderly.yaml.",
+					commands.Colorizer.Red(err.Error()),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	logrus.Info(commands.Colorizer.Sprintf("\nUsing environment %s.", commands.Colorizer.Bold(environmentName)))
+}
+
+// mustSetEnvironmentSecrets sets secrets of environment selected with --env in project, before publishing.
+func mustSetEnvironmentSecrets() {
+	if environment == nil || len(environment.Secrets) == 0 {
+		return
+	}
+
+	secrets, err := environment.ResolveSecrets(os.LookupEnv)
+	if err == nil {
+		err = r.Actions.SetSecrets(payloads.SetSecretsRequest{Secrets: secrets}, projectSlug)
+	}
+	if err != nil {
+		userError.LogErrorf(
+			"failed to set environment secrets: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to set secrets of environment %s: %s",
+					commands.Colorizer.Bold(environmentName),
+					commands.Colorizer.Red(err.Error()),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	logrus.Info(commands.Colorizer.Sprintf(
+		"\nSet secrets of environment %s in project %s.",
+		commands.Colorizer.Bold(environmentName),
+		commands.Colorizer.Bold(projectSlug),
+	))
+}
+
+// mustFilterActions keeps only specs selected with --only and --exclude.
+func mustFilterActions(actions *actionsModel.ProjectActions) {
+	specs, err := actions.Specs.Filter(onlyActions, excludeActions)
+	if err != nil {
+		userError.LogErrorf(
+			"invalid action filter: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Invalid --only or --exclude: %s. Check action names in tenderly.yaml.",
+					commands.Colorizer.Red(err.Error()),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	if len(specs) == 0 {
+		logrus.Error(commands.Colorizer.Red("No actions selected. Check --only and --exclude."))
+		os.Exit(1)
+	}
+	actions.Specs = specs
+}
+
+func isActionFilterSet() bool {
+	return len(onlyActions) > 0 || len(excludeActions) > 0
+}
+
+// mustBuildLocal runs every build step that doesn't need the Tenderly backend: trigger parsing and validation
+// against abis, generation of action event types, typescript and package.json checks, dependency installation,
+// compilation and bundling. Sets outDir and sourcesDir.
+func mustBuildLocal(actions *actionsModel.ProjectActions, abis actionsModel.ContractABIs) {
 	logrus.Info("\nBuilding actions:")
 	for actionName := range actions.Specs {
 		logrus.Info(
@@ -111,7 +249,7 @@ func buildFunc(cmd *cobra.Command, args []string) {
 		)
 		os.Exit(1)
 	}
-	mustParseAndValidateActions(actions)
+	mustParseAndValidateActions(actions, abis)
 
 	tsConfigExists := util.TsConfigExists(actions.Sources)
 	tsFileExists, tsFile := anyFunctionTsFileExists(actions)
@@ -171,16 +309,21 @@ func buildFunc(cmd *cobra.Command, args []string) {
 		if tsconfig.CompilerOptions.RootDir != nil && *tsconfig.CompilerOptions.RootDir != "" {
 			sourcesDir = filepath.Join(actions.Sources, *tsconfig.CompilerOptions.RootDir)
 		}
+		mustGenerateTypes(actions, abis)
 		mustInstallDependencies(actions.Sources)
 		mustBuildProject(actions.Sources, tsconfig)
 		mustExistCompiledFiles(outDir, actions)
 	}
 
-	sources = mustValidateAndGetSources(r, actions, projectSlug, sourcesDir)
-	logrus.Info(commands.Colorizer.Green("\nBuild completed."))
+	if actions.RuntimeBundle {
+		if tsconfig == nil {
+			mustInstallDependencies(actions.Sources)
+		}
+		outDir = mustBundle(actions, projectSlug, outDir)
+	}
 }
 
-func mustParseAndValidateActions(projectActions *actionsModel.ProjectActions) {
+func mustParseAndValidateActions(projectActions *actionsModel.ProjectActions, abis actionsModel.ContractABIs) {
 	for name, spec := range projectActions.Specs {
 		if spec.ExecutionType != actionsModel.ParallelExecutionType &&
 			spec.ExecutionType != actionsModel.SequentialExecutionType &&
@@ -217,16 +360,25 @@ func mustParseAndValidateActions(projectActions *actionsModel.ProjectActions) {
 	}
 
 	logrus.Info("\nValidating triggers configuration...")
+	nodes := specNodes(projectSlug)
 	errors := false
 	for name, spec := range projectActions.Specs {
-		validatorResponse := spec.TriggerParsed.Validate(actionsModel.ValidatorContext(name + ".trigger"))
-		for _, i := range validatorResponse.Infos {
-			logrus.Info(commands.Colorizer.Blue(i))
+		var diagnostics actionsModel.Diagnostics
+		response := spec.TriggerParsed.Validate(actionsModel.NewValidatorContext(name, "trigger"))
+		diagnostics.AddResponse(response)
+		if len(response.Errors) == 0 {
+			diagnostics.AddResponse(spec.TriggerParsed.ValidateSchedule(actionsModel.NewValidatorContext(name, "trigger"), time.Now()))
+		}
+		if len(response.Errors) == 0 && len(abis) > 0 {
+			diagnostics.AddResponse(spec.TriggerParsed.ValidateABI(actionsModel.NewValidatorContext(name, "trigger"), abis))
 		}
-		if len(validatorResponse.Errors) > 0 {
-			errors = true
-			for _, e := range validatorResponse.Errors {
-				logrus.Info(commands.Colorizer.Red(e))
+		diagnostics.Locate(nodes)
+		for _, d := range diagnostics {
+			if d.Severity == actionsModel.DiagnosticError {
+				errors = true
+				logrus.Info(commands.Colorizer.Red(d.String()))
+			} else {
+				logrus.Info(commands.Colorizer.Blue(d.String()))
 			}
 		}
 	}
@@ -238,11 +390,24 @@ func mustParseAndValidateActions(projectActions *actionsModel.ProjectActions) {
 
 func publishFunc(cmd *cobra.Command, args []string) {
 	buildFunc(cmd, args)
+	mustSetEnvironmentSecrets()
 	publish(r, actions, sources, projectSlug, outDir, false)
 }
 
 func deployFunc(cmd *cobra.Command, args []string) {
 	buildFunc(cmd, args)
+
+	plan := mustGetPlan()
+	printPlan(plan)
+	if !plan.HasChanges() {
+		logrus.Info(commands.Colorizer.Green("\nNo changes, nothing to deploy."))
+		return
+	}
+	if !deployYes {
+		mustConfirmDeploy()
+	}
+	mustSetEnvironmentSecrets()
+
 	publish(r, actions, sources, projectSlug, outDir, true)
 }
 
@@ -267,20 +432,19 @@ func publish(
 		)
 	}
 
-	logicZip, logicHash := util.MustZipAndHashDir(outDir, srcPathInZip, zipLimitBytes)
+	logicZip, logicManifest := zipAndHashLogic(actions)
+	logicHash := logicManifest.Hash()
 	if logicExist {
 		logicZip = nil
 	}
 
-	dependenciesDir := filepath.Join(actions.Sources, typescript.NodeModulesDir)
-	dependenciesZip, dependenciesHash := util.ZipAndHashDir(
-		dependenciesDir, nodeModulesPathInZip, zipLimitBytes,
-	)
+	dependenciesZip, dependenciesManifest := zipAndHashDependencies(actions)
+	dependenciesHash := dependenciesManifest.Hash()
 	if dependenciesExist {
 		dependenciesZip = nil
 	}
 
-	// TODO(marko): Send package-lock.json in publish request
+	// Manifests are not in the API definition, only their hashes are sent as layer versions
 	request := conjureactions.PublishRequest{
 		Actions:             actions.ToRequest(sources),
 		Deploy:              deploy,
@@ -289,6 +453,7 @@ func publish(
 		LogicVersion:        &logicHash,
 		DependenciesZip:     &dependenciesZip,
 		DependenciesVersion: &dependenciesHash,
+		DependenciesLock:    mustReadDependenciesLock(actions.Sources),
 	}
 
 	s := spinner.New(spinner.CharSets[33], 100*time.Millisecond)
@@ -328,6 +493,67 @@ func publish(
 	}
 }
 
+func zipAndHashLogic(actions *actionsModel.ProjectActions) ([]byte, *zip.Manifest) {
+	return util.MustZipAndHashDir(outDir, srcPathInZip, zipLimitBytes, mustLoadIgnore(actions.Sources))
+}
+
+// zipAndHashDependencies returns dependencies layer, empty if there are no dependencies or they are bundled
+// with actions.
+func zipAndHashDependencies(actions *actionsModel.ProjectActions) ([]byte, *zip.Manifest) {
+	if actions.RuntimeBundle {
+		return nil, nil
+	}
+	dependenciesDir := filepath.Join(actions.Sources, typescript.NodeModulesDir)
+	return util.ZipAndHashDir(
+		dependenciesDir, nodeModulesPathInZip, zipLimitBytes, mustLoadIgnore(actions.Sources),
+	)
+}
+
+// mustLoadIgnore loads exclude rules for zips from sources directory.
+func mustLoadIgnore(sourcesDir string) *zip.Ignore {
+	ignore, err := zip.LoadIgnore(sourcesDir)
+	if err != nil {
+		userError.LogErrorf(
+			"failed loading ignore file: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed reading %s.",
+					commands.Colorizer.Bold(commands.Colorizer.Red(filepath.Join(sourcesDir, zip.IgnoreFile))),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	return ignore
+}
+
+// mustReadDependenciesLock returns content of the lock file dependencies were installed from, or nil if there is none.
+func mustReadDependenciesLock(sourcesDir string) *string {
+	_, path, found := typescript.FindLockFile(sourcesDir)
+	if !found {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		userError.LogErrorf(
+			"failed reading lock file: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed reading lock file %s.",
+					commands.Colorizer.Bold(commands.Colorizer.Red(path)),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+
+	lock := string(content)
+	return &lock
+}
+
 func mustBuildProject(sourcesDir string, tsconfig *typescript.TsConfig) {
 	if tsconfig == nil {
 		return
@@ -336,7 +562,7 @@ func mustBuildProject(sourcesDir string, tsconfig *typescript.TsConfig) {
 	util.RemoveDirWithContent(filepath.Join(sourcesDir, *tsconfig.CompilerOptions.OutDir))
 
 	logrus.Info("\nBuilding actions...")
-	cmd := exec.Command("npm", "--prefix", sourcesDir, "run", typescript.DefaultBuildScriptName)
+	cmd := mustGetPackageManager(sourcesDir).RunCommand(sourcesDir, typescript.DefaultBuildScriptName)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
@@ -349,7 +575,7 @@ func mustBuildProject(sourcesDir string, tsconfig *typescript.TsConfig) {
 				commands.Colorizer.Sprintf(
 					"Failed to run: %s.",
 					commands.Colorizer.Bold(
-						commands.Colorizer.Red(fmt.Sprintf("npm --prefix %s run build", sourcesDir)),
+						commands.Colorizer.Red(typescript.CommandString(cmd)),
 					),
 				),
 			),
@@ -434,13 +660,13 @@ func mustValidate(
 		DependenciesVersion: nil,
 	}
 
-	_, logicHash := util.MustZipAndHashDir(outDir, srcPathInZip, zipLimitBytes)
+	_, logicManifest := zipAndHashLogic(actions)
+	logicHash := logicManifest.Hash()
 
 	request.LogicVersion = &logicHash
 
-	dependenciesDir := filepath.Join(actions.Sources, typescript.NodeModulesDir)
-
-	_, dependenciesHash := util.ZipAndHashDir(dependenciesDir, nodeModulesPathInZip, zipLimitBytes)
+	_, dependenciesManifest := zipAndHashDependencies(actions)
+	dependenciesHash := dependenciesManifest.Hash()
 	request.DependenciesVersion = &dependenciesHash
 
 	response, err := r.Actions.Validate(request, projectSlug)
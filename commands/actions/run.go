@@ -0,0 +1,298 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	conjureactions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+const (
+	runnerFileName        = "runner.js"
+	runnerPayloadFileName = "payload.json"
+	runnerResultFileName  = "result.json"
+)
+
+var runPayloadFile string
+var runStorageFile string
+
+// Runner mimics the Web3 Actions runtime: it converts execution payload to event, provides context with secrets and
+// storage and invokes the action function. Storage is kept in memory and optionally persisted to a JSON file.
+var runnerScript = `const fs = require('fs');
+const path = require('path');
+const util = require('util');
+
+const [modulePath, functionName, payloadPath, resultPath, storagePath] = process.argv.slice(2);
+const execution = JSON.parse(fs.readFileSync(payloadPath, 'utf8'));
+
+class LocalStorage {
+	constructor(data) { this.data = data; }
+	async getStr(key) { return this.data[key] === undefined ? '' : String(this.data[key]); }
+	async putStr(key, value) { this.data[key] = String(value); }
+	async getNumber(key) { return this.data[key] === undefined ? 0 : Number(this.data[key]); }
+	async putNumber(key, value) { this.data[key] = Number(value); }
+	async getBigInt(key) { return this.data[key] === undefined ? BigInt(0) : BigInt(this.data[key]); }
+	async putBigInt(key, value) { this.data[key] = value.toString(); }
+	async getJson(key) { return this.data[key] === undefined ? {} : this.data[key]; }
+	async putJson(key, value) { this.data[key] = value; }
+	async delete(key) { delete this.data[key]; }
+}
+
+class LocalSecrets {
+	constructor(secrets) { this.secrets = secrets || {}; }
+	async get(key) {
+		if (!(key in this.secrets)) {
+			throw new Error('Secret with key ' + key + ' not found');
+		}
+		return this.secrets[key];
+	}
+}
+
+function toEvent(payload) {
+	switch (payload.type) {
+		case 'periodic':
+			return { time: new Date(payload.periodic.timestamp * 1000) };
+		case 'webhook':
+			return { time: new Date(payload.webhook.timestamp * 1000), payload: payload.webhook.body };
+		case 'block':
+		case 'transaction':
+		case 'transactionsimple':
+		case 'alert':
+			return payload[payload.type];
+	}
+	throw new Error('Unsupported payload type ' + payload.type);
+}
+
+const logs = [];
+for (const [method, severity] of [['log', 'INFO'], ['info', 'INFO'], ['debug', 'DEBUG'], ['warn', 'WARN'], ['error', 'ERROR']]) {
+	const original = console[method].bind(console);
+	console[method] = (...args) => {
+		logs.push({ time: new Date().toISOString(), severity: severity, message: util.format(...args) });
+		original(...args);
+	};
+}
+
+const storageData = storagePath && fs.existsSync(storagePath) ? JSON.parse(fs.readFileSync(storagePath, 'utf8')) : {};
+const context = {
+	storage: new LocalStorage(storageData),
+	secrets: new LocalSecrets(execution.secrets && execution.secrets.secrets),
+};
+
+function finish(result) {
+	if (storagePath) {
+		fs.writeFileSync(storagePath, JSON.stringify(storageData, null, 2));
+	}
+	result.logs = logs;
+	fs.writeFileSync(resultPath, JSON.stringify(result));
+}
+
+(async () => {
+	const fn = require(path.resolve(modulePath))[functionName];
+	if (typeof fn !== 'function') {
+		throw new Error('Function ' + functionName + ' is not exported from ' + modulePath);
+	}
+	const result = await fn(context, toEvent(execution.event));
+	finish({ success: true, result: result === undefined ? null : result });
+})().catch((err) => {
+	console.error(err);
+	finish({ success: false, error: { name: err.name || 'Error', message: err.message || String(err), stacktrace: err.stack || '' } });
+	process.exitCode = 1;
+});
+`
+
+// runResult is written by the runner after the action function finishes.
+type runResult struct {
+	Success bool                         `json:"success"`
+	Result  interface{}                  `json:"result"`
+	Error   *conjureactions.CallError    `json:"error"`
+	Logs    []conjureactions.CallLogLine `json:"logs"`
+}
+
+func init() {
+	runCmd.PersistentFlags().StringVar(
+		&runPayloadFile, "payload", "",
+		"Path to the JSON event payload. If not provided, a synthetic event is generated from the action trigger.",
+	)
+	runCmd.PersistentFlags().StringVar(
+		&runStorageFile, "storage", "",
+		"Path to the JSON file used as local storage. It is created if missing and updated after the run.",
+	)
+
+	actionsCmd.AddCommand(runCmd)
+}
+
+var runCmd = &cobra.Command{
+	Use:   "run <action-name>",
+	Short: "Run action locally",
+	Long: "Builds actions and runs the action function locally under Node. " +
+		"The action receives a synthetic event generated from its trigger, or the event from the --payload file.",
+	Args: cobra.ExactArgs(1),
+	Run:  runFunc,
+}
+
+func runFunc(cmd *cobra.Command, args []string) {
+	actionName := args[0]
+
+	allActions := MustGetActions()
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+
+	spec := mustGetActionSpec(actions, projectSlug, actionName)
+
+	mustBuildLocal(actions, loadBuildContractABIs(actions))
+	mustRunAction(actionName, spec, runPayloadFile, runStorageFile)
+}
+
+// mustRunAction runs built action with event from payloadFile, or synthetic event if payloadFile is empty.
+func mustRunAction(actionName string, spec *actionsModel.ActionSpec, payloadFile string, storageFile string) {
+	var payload conjureactions.Payload
+	if payloadFile != "" {
+		payload = mustReadPayload(payloadFile)
+	} else {
+		var err error
+		payload, err = actionsModel.NewPayload(spec.TriggerParsed, time.Now())
+		if err != nil {
+			userError.LogErrorf(
+				"failed to create payload: %s",
+				userError.NewUserError(
+					err,
+					commands.Colorizer.Sprintf(
+						"Failed to create event for action %s.",
+						commands.Colorizer.Bold(commands.Colorizer.Red(actionName)),
+					),
+				),
+			)
+			os.Exit(1)
+		}
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf(
+		"\nRunning action %s locally...\n", commands.Colorizer.Bold(commands.Colorizer.Green(actionName)),
+	))
+
+	result := mustRunLocal(spec, actionsModel.NewExecutionPayload(payload, nil), storageFile)
+	if !result.Success {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"\nAction %s failed: %s",
+			commands.Colorizer.Bold(commands.Colorizer.Red(actionName)),
+			commands.Colorizer.Red(result.Error.Message),
+		))
+		os.Exit(1)
+	}
+
+	logrus.Info(commands.Colorizer.Green("\nAction finished successfully."))
+	if result.Result != nil {
+		content, _ := json.MarshalIndent(result.Result, "", "  ")
+		logrus.Info(commands.Colorizer.Sprintf("Returned: %s", string(content)))
+	}
+}
+
+func mustReadPayload(path string) conjureactions.Payload {
+	var payload conjureactions.Payload
+	err := json.Unmarshal([]byte(util.ReadFile(path)), &payload)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to parse payload: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to parse payload %s. Expected JSON with %s and matching event field, e.g. %s.",
+					commands.Colorizer.Bold(commands.Colorizer.Red(path)),
+					commands.Colorizer.Bold("type"),
+					commands.Colorizer.Bold(`{"type": "block", "block": {...}}`),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	return payload
+}
+
+// mustRunLocal runs compiled action function from outDir with node and returns result of the execution.
+// Output of the action is streamed to stdout / stderr.
+func mustRunLocal(spec *actionsModel.ActionSpec, execution conjureactions.ExecutionPayload, storagePath string) *runResult {
+	result, err := runLocal(spec, execution, storagePath)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to run action locally: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to run action locally: %s",
+					commands.Colorizer.Red(err.Error()),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	return result
+}
+
+func runLocal(spec *actionsModel.ActionSpec, execution conjureactions.ExecutionPayload, storagePath string) (*runResult, error) {
+	nodePath, err := exec.LookPath("node")
+	if err != nil {
+		return nil, errors.Wrap(err, "node executable not found in PATH")
+	}
+
+	internalLocator, err := actionsModel.NewInternalLocator(spec.Function)
+	if err != nil {
+		return nil, err
+	}
+	modulePath := filepath.Join(outDir, fmt.Sprintf("%s.js", internalLocator.Path))
+	if !util.ExistFile(modulePath) {
+		return nil, fmt.Errorf("compiled file %s not found", modulePath)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "tenderly-actions-run")
+	if err != nil {
+		return nil, errors.Wrap(err, "create temporary directory")
+	}
+	defer os.RemoveAll(tmpDir)
+
+	runnerPath := filepath.Join(tmpDir, runnerFileName)
+	err = os.WriteFile(runnerPath, []byte(runnerScript), os.FileMode(0644))
+	if err != nil {
+		return nil, errors.Wrap(err, "write runner")
+	}
+
+	payloadContent, err := json.Marshal(execution)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal payload")
+	}
+	payloadPath := filepath.Join(tmpDir, runnerPayloadFileName)
+	err = os.WriteFile(payloadPath, payloadContent, os.FileMode(0644))
+	if err != nil {
+		return nil, errors.Wrap(err, "write payload")
+	}
+
+	resultPath := filepath.Join(tmpDir, runnerResultFileName)
+	cmd := exec.Command(nodePath, runnerPath, modulePath, internalLocator.FunctionName, payloadPath, resultPath, storagePath)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	// Failed action is reported in result, missing result means runner itself failed
+	_ = cmd.Run()
+
+	resultContent, err := os.ReadFile(resultPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "runner did not produce result")
+	}
+
+	var result runResult
+	err = json.Unmarshal(resultContent, &result)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse runner result")
+	}
+	return &result, nil
+}
@@ -0,0 +1,154 @@
+package actions
+
+import (
+	"os"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+var scheduleCount int
+var scheduleTimezone string
+
+func init() {
+	scheduleCmd.PersistentFlags().IntVar(&scheduleCount, "count", 5, "Number of upcoming runs to print.")
+	scheduleCmd.PersistentFlags().StringVar(
+		&scheduleTimezone, "timezone", "Local",
+		"Timezone in which upcoming runs are printed next to UTC, e.g. Europe/Belgrade.",
+	)
+
+	actionsCmd.AddCommand(scheduleCmd)
+}
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule <action-name>",
+	Short: "Preview schedule of periodic trigger",
+	Long: "Prints upcoming runs of action with periodic trigger, in UTC and in selected timezone, " +
+		"and describes its schedule. Warns about schedules firing more often than every 5 minutes " +
+		"and exits with non-zero code if schedule never fires.",
+	Args: cobra.ExactArgs(1),
+	Run:  scheduleFunc,
+}
+
+type scheduleOutput struct {
+	Action      string      `json:"action"`
+	Cron        string      `json:"cron"`
+	Interval    *string     `json:"interval,omitempty"`
+	Description string      `json:"description"`
+	Timezone    string      `json:"timezone"`
+	Runs        []time.Time `json:"runs"`
+	Warnings    []string    `json:"warnings,omitempty"`
+	Errors      []string    `json:"errors,omitempty"`
+}
+
+func scheduleFunc(cmd *cobra.Command, args []string) {
+	actionName := args[0]
+
+	allActions := MustGetActions()
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+	spec := mustGetActionSpec(actions, projectSlug, actionName)
+
+	location, err := time.LoadLocation(scheduleTimezone)
+	if err != nil {
+		userError.LogErrorf(
+			"invalid timezone: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf("Invalid --timezone %s.", commands.Colorizer.Bold(commands.Colorizer.Red(scheduleTimezone))),
+			),
+		)
+		os.Exit(1)
+	}
+
+	err = spec.Parse()
+	if err == nil && spec.TriggerParsed.Periodic == nil {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"Action %s has %s trigger, only %s triggers have schedule.",
+			commands.Colorizer.Bold(commands.Colorizer.Red(actionName)),
+			commands.Colorizer.Bold(spec.TriggerParsed.Type),
+			commands.Colorizer.Bold(actionsModel.PeriodicType),
+		))
+		os.Exit(1)
+	}
+	if err != nil {
+		userError.LogErrorf(
+			"failed parsing action trigger with %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed parsing action trigger for %s",
+					commands.Colorizer.Bold(commands.Colorizer.Red(actionName)),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+
+	periodic := spec.TriggerParsed.Periodic
+	ctx := actionsModel.NewValidatorContext(actionName, "trigger", "periodic")
+	response := periodic.Validate(ctx)
+	if len(response.Errors) == 0 {
+		response.Merge(periodic.ValidateSchedule(ctx, time.Now()))
+	}
+	output := scheduleOutput{
+		Action:   actionName,
+		Interval: periodic.Interval,
+		Timezone: location.String(),
+		Warnings: response.Infos,
+		Errors:   response.Errors,
+	}
+	if periodic.Cron != nil {
+		output.Cron = *periodic.Cron
+		schedule, err := actionsModel.NewSchedule(*periodic.Cron)
+		if err == nil {
+			output.Description = schedule.Describe()
+			output.Runs = schedule.Next(time.Now(), scheduleCount)
+		}
+	}
+
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(output)
+	} else {
+		printSchedule(output, location)
+	}
+	if len(output.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+func printSchedule(output scheduleOutput, location *time.Location) {
+	logrus.Info(commands.Colorizer.Sprintf("\nSchedule of action %s:", commands.Colorizer.Bold(output.Action)))
+	if output.Interval != nil {
+		logrus.Info(commands.Colorizer.Sprintf("  Interval: %s", commands.Colorizer.Bold(*output.Interval)))
+	}
+	if output.Cron != "" {
+		logrus.Info(commands.Colorizer.Sprintf("  Cron: %s", commands.Colorizer.Bold(output.Cron)))
+	}
+	if output.Description != "" {
+		logrus.Info(commands.Colorizer.Sprintf("  Runs %s (UTC)", output.Description))
+	}
+
+	if len(output.Runs) > 0 {
+		logrus.Info(commands.Colorizer.Sprintf("\nNext %d runs:", len(output.Runs)))
+		for _, run := range output.Runs {
+			logrus.Info(commands.Colorizer.Sprintf(
+				"  %s  %s",
+				commands.Colorizer.Bold(run.UTC().Format("2006-01-02 15:04 MST")),
+				commands.Colorizer.Faint(run.In(location).Format("2006-01-02 15:04 MST")),
+			))
+		}
+	}
+
+	for _, warning := range output.Warnings {
+		logrus.Info(commands.Colorizer.Yellow("\n" + warning))
+	}
+	for _, msg := range output.Errors {
+		logrus.Error(commands.Colorizer.Red("\n" + msg))
+	}
+}
@@ -0,0 +1,185 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	"github.com/tenderly/tenderly-cli/rest/payloads"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+var secretsEnvFile string
+
+func init() {
+	secretsSetCmd.PersistentFlags().StringVar(
+		&secretsEnvFile, "from-env-file", "",
+		"Import secrets from dotenv file. Secrets passed as arguments override values from the file.",
+	)
+
+	secretsCmd.AddCommand(secretsSetCmd)
+	secretsCmd.AddCommand(secretsListCmd)
+	secretsCmd.AddCommand(secretsUnsetCmd)
+	actionsCmd.AddCommand(secretsCmd)
+}
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets",
+	Short: "Manage secrets available to actions through context.secrets",
+}
+
+var secretsSetCmd = &cobra.Command{
+	Use:   "set [KEY=VALUE | KEY]...",
+	Short: "Create or update secrets",
+	Long: "Creates or updates secrets in project. Value is prompted for keys passed without value, " +
+		"so it doesn't end up in shell history.",
+	Run: secretsSetFunc,
+}
+
+var secretsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List secrets with masked values",
+	Args:  cobra.NoArgs,
+	Run:   secretsListFunc,
+}
+
+var secretsUnsetCmd = &cobra.Command{
+	Use:   "unset KEY...",
+	Short: "Delete secrets",
+	Args:  cobra.MinimumNArgs(1),
+	Run:   secretsUnsetFunc,
+}
+
+func secretsSetFunc(cmd *cobra.Command, args []string) {
+	secrets := make(map[string]string)
+	if secretsEnvFile != "" {
+		secrets = util.MustLoadDotEnv(secretsEnvFile)
+	}
+	for _, arg := range args {
+		key, value, hasValue := strings.Cut(arg, "=")
+		if !hasValue {
+			value = mustPromptSecret(key)
+		}
+		secrets[key] = value
+	}
+	if len(secrets) == 0 {
+		logrus.Error("No secrets to set. Pass KEY=VALUE arguments or --from-env-file.")
+		os.Exit(1)
+	}
+	for key := range secrets {
+		mustValidateSecretKey(key)
+	}
+
+	mustInitRemoteProject()
+	err := r.Actions.SetSecrets(payloads.SetSecretsRequest{Secrets: secrets}, projectSlug)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to set secrets: %s",
+			userError.NewUserError(err, commands.Colorizer.Sprintf("Failed to set secrets: %s", err.Error())),
+		)
+		os.Exit(1)
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf("Set secrets in project %s:", commands.Colorizer.Bold(projectSlug)))
+	printSecrets(secrets)
+}
+
+func secretsListFunc(cmd *cobra.Command, args []string) {
+	mustInitRemoteProject()
+	response, err := r.Actions.GetSecrets(projectSlug)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to get secrets: %s",
+			userError.NewUserError(err, commands.Colorizer.Sprintf("Failed to get secrets: %s", err.Error())),
+		)
+		os.Exit(1)
+	}
+
+	if commands.IsJSONOutput() {
+		masked := make(map[string]string)
+		for key, value := range response.Secrets {
+			masked[key] = maskSecret(value)
+		}
+		commands.OutputJSON(masked)
+		return
+	}
+
+	if len(response.Secrets) == 0 {
+		logrus.Info(commands.Colorizer.Sprintf("No secrets in project %s.", commands.Colorizer.Bold(projectSlug)))
+		return
+	}
+	logrus.Info(commands.Colorizer.Sprintf("Secrets in project %s:", commands.Colorizer.Bold(projectSlug)))
+	printSecrets(response.Secrets)
+}
+
+func secretsUnsetFunc(cmd *cobra.Command, args []string) {
+	mustInitRemoteProject()
+	for _, key := range args {
+		err := r.Actions.DeleteSecret(key, projectSlug)
+		if err != nil {
+			userError.LogErrorf(
+				"failed to delete secret: %s",
+				userError.NewUserError(
+					err,
+					commands.Colorizer.Sprintf(
+						"Failed to delete secret %s: %s",
+						commands.Colorizer.Bold(commands.Colorizer.Red(key)),
+						err.Error(),
+					),
+				),
+			)
+			os.Exit(1)
+		}
+		logrus.Info(commands.Colorizer.Sprintf("Deleted secret %s.", commands.Colorizer.Bold(key)))
+	}
+}
+
+func mustValidateSecretKey(key string) {
+	if key == "" || strings.ContainsAny(key, " \t\n/") {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"Invalid secret key %s. Keys can't be empty or contain whitespace or '/'.",
+			commands.Colorizer.Bold(commands.Colorizer.Red(fmt.Sprintf("%q", key))),
+		))
+		os.Exit(1)
+	}
+}
+
+func mustPromptSecret(key string) string {
+	prompt := promptui.Prompt{
+		Label: fmt.Sprintf("Value for %s", key),
+		Mask:  '*',
+	}
+	value, err := prompt.Run()
+	if err != nil {
+		userError.LogErrorf("prompt secret failed: %s", err)
+		os.Exit(1)
+	}
+	return value
+}
+
+func printSecrets(secrets map[string]string) {
+	keys := make([]string, 0, len(secrets))
+	for key := range secrets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		logrus.Info(commands.Colorizer.Sprintf("- %s: %s", commands.Colorizer.Bold(key), maskSecret(secrets[key])))
+	}
+}
+
+// maskSecret hides secret value completely, only empty values are shown as such.
+func maskSecret(value string) string {
+	if value == "" {
+		return "(empty)"
+	}
+	return "********"
+}
@@ -0,0 +1,119 @@
+package actions
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+var stopAll bool
+var resumeAll bool
+
+func init() {
+	stopCmd.PersistentFlags().BoolVar(&stopAll, "all", false, "Stop all actions in project.")
+	resumeCmd.PersistentFlags().BoolVar(&resumeAll, "all", false, "Resume all actions in project.")
+
+	actionsCmd.AddCommand(stopCmd)
+	actionsCmd.AddCommand(resumeCmd)
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop [action-name...]",
+	Short: "Stop deployed actions",
+	Long: "Stopped actions ignore events until they are resumed, even after new publish or deploy. " +
+		"Pass action names from tenderly.yaml or --all to stop every action in project.",
+	Run: stopFunc,
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume [action-name...]",
+	Short: "Resume stopped actions",
+	Long:  "Pass action names from tenderly.yaml or --all to resume every action in project.",
+	Run:   resumeFunc,
+}
+
+func stopFunc(cmd *cobra.Command, args []string) {
+	names, actionIDs := mustSelectActionsForToggle(args, stopAll)
+
+	err := r.Actions.Stop(generatedActions.StopRequest{Actions: actionIDs}, projectSlug)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to stop actions: %s",
+			userError.NewUserError(err, commands.Colorizer.Sprintf("Failed to stop actions: %s", err.Error())),
+		)
+		os.Exit(1)
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf(
+		"Stopped %s in project %s.",
+		commands.Colorizer.Bold(commands.Colorizer.Red(names)),
+		commands.Colorizer.Bold(projectSlug),
+	))
+}
+
+func resumeFunc(cmd *cobra.Command, args []string) {
+	names, actionIDs := mustSelectActionsForToggle(args, resumeAll)
+
+	err := r.Actions.Resume(generatedActions.ResumeRequest{Actions: actionIDs}, projectSlug)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to resume actions: %s",
+			userError.NewUserError(err, commands.Colorizer.Sprintf("Failed to resume actions: %s", err.Error())),
+		)
+		os.Exit(1)
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf(
+		"Resumed %s in project %s.",
+		commands.Colorizer.Bold(commands.Colorizer.Green(names)),
+		commands.Colorizer.Bold(projectSlug),
+	))
+}
+
+// mustSelectActionsForToggle maps action names from tenderly.yaml to ids of published actions. Empty ids mean all
+// actions in project, which is how stop and resume requests are defined.
+func mustSelectActionsForToggle(names []string, all bool) (string, []string) {
+	if all == (len(names) > 0) {
+		logrus.Error("Specify action names or --all, but not both.")
+		os.Exit(1)
+	}
+
+	mustInitRemoteProject()
+	if all {
+		return "all actions", nil
+	}
+
+	for _, name := range names {
+		mustGetActionSpec(actions, projectSlug, name)
+	}
+
+	remoteIDs := make(map[string]string)
+	for _, action := range mustGetRemoteActions(r, projectSlug) {
+		remoteIDs[action.Name] = action.Id
+	}
+
+	var actionIDs []string
+	for _, name := range names {
+		id, exists := remoteIDs[name]
+		if !exists {
+			logrus.Error(commands.Colorizer.Sprintf(
+				"Action %s is not published in project %s.",
+				commands.Colorizer.Bold(commands.Colorizer.Red(name)),
+				commands.Colorizer.Bold(projectSlug),
+			))
+			os.Exit(1)
+		}
+		actionIDs = append(actionIDs, id)
+	}
+
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", "), actionIDs
+}
@@ -0,0 +1,251 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/rest/payloads"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+const storagePreviewLength = 60
+
+var storageID string
+var storagePutType string
+var storageExportFile string
+
+func init() {
+	storageCmd.PersistentFlags().StringVar(
+		&storageID, "storage-id", "",
+		"Id of the storage to use, e.g. storage of a single execution. If not provided, project storage is used.",
+	)
+	storagePutCmd.PersistentFlags().StringVar(
+		&storagePutType, "type", actionsModel.StorageTypeStr,
+		fmt.Sprintf("Type of the value, one of %s.", strings.Join(actionsModel.StorageTypes, ", ")),
+	)
+	storageExportCmd.PersistentFlags().StringVar(
+		&storageExportFile, "file", "",
+		"File to write storage to. If not provided, storage is written to stdout.",
+	)
+
+	storageCmd.AddCommand(storageGetCmd)
+	storageCmd.AddCommand(storagePutCmd)
+	storageCmd.AddCommand(storageDeleteCmd)
+	storageCmd.AddCommand(storageListCmd)
+	storageCmd.AddCommand(storageExportCmd)
+	storageCmd.AddCommand(storageImportCmd)
+	actionsCmd.AddCommand(storageCmd)
+}
+
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Inspect and seed storage available to actions through context.storage",
+	Long: "Exported storage is a JSON object of keys and stored values, " +
+		"the same format used by tenderly actions run --storage.",
+}
+
+var storageGetCmd = &cobra.Command{
+	Use:   "get KEY",
+	Short: "Print stored value",
+	Args:  cobra.ExactArgs(1),
+	Run:   storageGetFunc,
+}
+
+var storagePutCmd = &cobra.Command{
+	Use:   "put KEY VALUE",
+	Short: "Store value",
+	Args:  cobra.ExactArgs(2),
+	Run:   storagePutFunc,
+}
+
+var storageDeleteCmd = &cobra.Command{
+	Use:   "delete KEY...",
+	Short: "Delete stored values",
+	Args:  cobra.MinimumNArgs(1),
+	Run:   storageDeleteFunc,
+}
+
+var storageListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List stored keys with value preview",
+	Args:  cobra.NoArgs,
+	Run:   storageListFunc,
+}
+
+var storageExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export storage to JSON",
+	Args:  cobra.NoArgs,
+	Run:   storageExportFunc,
+}
+
+var storageImportCmd = &cobra.Command{
+	Use:   "import FILE",
+	Short: "Import values from JSON file",
+	Long:  "Stores every key from JSON object in FILE. Keys not present in the file are kept.",
+	Args:  cobra.ExactArgs(1),
+	Run:   storageImportFunc,
+}
+
+func storageGetFunc(cmd *cobra.Command, args []string) {
+	key := args[0]
+
+	mustInitRemoteProject()
+	response, err := r.Actions.GetStorageValue(projectSlug, storageID, key)
+	if err != nil {
+		mustFailStorage(err, fmt.Sprintf("Failed to get value for key %s", key))
+	}
+
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(response.Value)
+		return
+	}
+	logrus.Info(formatStorageValue(response.Value))
+}
+
+func storagePutFunc(cmd *cobra.Command, args []string) {
+	key, value := args[0], args[1]
+
+	encoded, err := actionsModel.EncodeStorageValue(value, storagePutType)
+	if err != nil {
+		userError.LogErrorf("invalid storage value: %s", userError.NewUserError(err, err.Error()))
+		os.Exit(1)
+	}
+
+	mustInitRemoteProject()
+	err = r.Actions.PutStorage(
+		payloads.PutStorageRequest{Values: map[string]json.RawMessage{key: encoded}},
+		projectSlug,
+		storageID,
+	)
+	if err != nil {
+		mustFailStorage(err, fmt.Sprintf("Failed to store value for key %s", key))
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf("Stored %s = %s.", commands.Colorizer.Bold(key), string(encoded)))
+}
+
+func storageDeleteFunc(cmd *cobra.Command, args []string) {
+	mustInitRemoteProject()
+	for _, key := range args {
+		err := r.Actions.DeleteStorageValue(projectSlug, storageID, key)
+		if err != nil {
+			mustFailStorage(err, fmt.Sprintf("Failed to delete key %s", key))
+		}
+		logrus.Info(commands.Colorizer.Sprintf("Deleted %s.", commands.Colorizer.Bold(key)))
+	}
+}
+
+func storageListFunc(cmd *cobra.Command, args []string) {
+	storage := mustGetStorage()
+
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(sortedStorageKeys(storage))
+		return
+	}
+
+	if len(storage) == 0 {
+		logrus.Info("Storage is empty.")
+		return
+	}
+	for _, key := range sortedStorageKeys(storage) {
+		preview := formatStorageValue(storage[key])
+		if len(preview) > storagePreviewLength {
+			preview = preview[:storagePreviewLength] + "..."
+		}
+		logrus.Info(commands.Colorizer.Sprintf("- %s: %s", commands.Colorizer.Bold(key), preview))
+	}
+}
+
+func storageExportFunc(cmd *cobra.Command, args []string) {
+	storage := mustGetStorage()
+
+	content, err := json.MarshalIndent(storage, "", "  ")
+	if err != nil {
+		mustFailStorage(err, "Failed to encode storage")
+	}
+	if storageExportFile == "" {
+		fmt.Println(string(content))
+		return
+	}
+
+	util.CreateFileWithContent(storageExportFile, string(content))
+	logrus.Info(commands.Colorizer.Sprintf(
+		"Exported %d keys to %s.", len(storage), commands.Colorizer.Bold(storageExportFile),
+	))
+}
+
+func storageImportFunc(cmd *cobra.Command, args []string) {
+	path := args[0]
+
+	var values map[string]json.RawMessage
+	err := json.Unmarshal([]byte(util.ReadFile(path)), &values)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to parse storage file: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to parse %s. Expected JSON object of keys and values.",
+					commands.Colorizer.Bold(commands.Colorizer.Red(path)),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+
+	mustInitRemoteProject()
+	err = r.Actions.PutStorage(payloads.PutStorageRequest{Values: values}, projectSlug, storageID)
+	if err != nil {
+		mustFailStorage(err, "Failed to import storage")
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf("Imported %d keys from %s.", len(values), commands.Colorizer.Bold(path)))
+}
+
+func mustGetStorage() map[string]json.RawMessage {
+	mustInitRemoteProject()
+	response, err := r.Actions.GetStorage(projectSlug, storageID)
+	if err != nil {
+		mustFailStorage(err, "Failed to get storage")
+	}
+	if response.Storage == nil {
+		return make(map[string]json.RawMessage)
+	}
+	return response.Storage
+}
+
+func mustFailStorage(err error, message string) {
+	userError.LogErrorf(
+		"storage request failed: %s",
+		userError.NewUserError(err, commands.Colorizer.Sprintf("%s: %s", message, err.Error())),
+	)
+	os.Exit(1)
+}
+
+func sortedStorageKeys(storage map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(storage))
+	for key := range storage {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatStorageValue prints strings without quotes, everything else as JSON.
+func formatStorageValue(value json.RawMessage) string {
+	var str string
+	if err := json.Unmarshal(value, &str); err == nil {
+		return str
+	}
+	return string(value)
+}
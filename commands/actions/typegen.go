@@ -0,0 +1,121 @@
+package actions
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+// mustGenerateTypes writes typescript module of every action into sources, with event type narrowed by its trigger.
+// Modules which didn't change are not rewritten, so watch mode doesn't rebuild because of them.
+// Events are decoded for contracts whose ABIs are in abis.
+func mustGenerateTypes(actions *actionsModel.ProjectActions, abis actionsModel.ContractABIs) {
+	dir := filepath.Join(sourcesDir, actionsModel.TypesDir)
+
+	names := make([]string, 0, len(actions.Specs))
+	for name := range actions.Specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		content := actionsModel.GenerateTypes(name, actions.Specs[name].TriggerParsed, abis)
+		path := filepath.Join(dir, name+".ts")
+		written, err := writeIfChanged(path, []byte(content))
+		if err != nil {
+			userError.LogErrorf(
+				"failed to generate types: %s",
+				userError.NewUserError(
+					err,
+					commands.Colorizer.Sprintf(
+						"Failed to generate types of action %s: %s",
+						commands.Colorizer.Bold(name),
+						commands.Colorizer.Red(err.Error()),
+					),
+				),
+			)
+			os.Exit(1)
+		}
+		if written {
+			logrus.Info(commands.Colorizer.Sprintf("Generated types of action %s in %s", commands.Colorizer.Bold(name), path))
+		}
+	}
+
+	// Filtered actions are still part of the project, so their modules are kept.
+	if isActionFilterSet() {
+		return
+	}
+	removed, err := removeStaleTypes(dir, actions.Specs)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to remove stale types: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf(
+					"Failed to remove types of removed actions: %s",
+					commands.Colorizer.Red(err.Error()),
+				),
+			),
+		)
+		os.Exit(1)
+	}
+	for _, path := range removed {
+		logrus.Info(commands.Colorizer.Sprintf("Removed stale types %s", commands.Colorizer.Bold(path)))
+	}
+}
+
+// removeStaleTypes deletes typescript modules in dir which don't belong to any of specs, left by renamed or
+// removed actions. Returns paths of deleted files.
+func removeStaleTypes(dir string, specs actionsModel.NamedActionSpecs) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "read directory %s", dir)
+	}
+
+	var removed []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".ts" {
+			continue
+		}
+		if _, ok := specs[strings.TrimSuffix(name, ".ts")]; ok {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		err = os.Remove(path)
+		if err != nil {
+			return removed, errors.Wrapf(err, "remove %s", path)
+		}
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+func writeIfChanged(path string, content []byte) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, content) {
+		return false, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), os.FileMode(0755))
+	if err != nil {
+		return false, errors.Wrapf(err, "create directory %s", filepath.Dir(path))
+	}
+	err = os.WriteFile(path, content, os.FileMode(0644))
+	if err != nil {
+		return false, errors.Wrapf(err, "write %s", path)
+	}
+	return true, nil
+}
@@ -0,0 +1,434 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	"github.com/tenderly/tenderly-cli/commands/util/packagejson"
+	"github.com/tenderly/tenderly-cli/config"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/typescript"
+	"github.com/tenderly/tenderly-cli/zip"
+)
+
+var validateOffline bool
+
+func init() {
+	validateCmd.PersistentFlags().BoolVar(
+		&validateOffline, "offline", false,
+		"Validate locally, without login and without Tenderly API. Doesn't install dependencies or compile sources.",
+	)
+
+	actionsCmd.AddCommand(validateCmd)
+}
+
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate actions configuration and sources",
+	Long: "Without --offline actions are built and validated by Tenderly, same as tenderly actions build. " +
+		"With --offline actions are validated locally, without login, Tenderly API, installing dependencies " +
+		"or compiling sources: tenderly.yaml, triggers, function locators, tsconfig, package.json dependencies " +
+		"against node_modules and zip size limits are checked, periodic schedules that never fire and generated " +
+		"types that are missing or out of date are reported, and trigger function and event names and parameter " +
+		"conditions are checked against ABIs of contracts in build directory of configured provider. " +
+		"Every problem is reported with its line and column in tenderly.yaml, " +
+		"with --output json also as GitHub check run annotations. " +
+		"If tenderly.yaml has several projects and input is not a terminal, --project is required. " +
+		"Exits with non-zero code if any error is found.",
+	Args: cobra.NoArgs,
+	Run:  validateFunc,
+}
+
+type validateOutput struct {
+	Project     string                   `json:"project,omitempty"`
+	Valid       bool                     `json:"valid"`
+	Diagnostics actionsModel.Diagnostics `json:"diagnostics"`
+	// Diagnostics located in tenderly.yaml, in the format of GitHub check run annotations
+	Annotations []actionsModel.Annotation `json:"annotations,omitempty"`
+}
+
+func validateFunc(cmd *cobra.Command, args []string) {
+	if !validateOffline {
+		buildFunc(cmd, args)
+		return
+	}
+
+	configName := configFileName()
+	var diagnostics actionsModel.Diagnostics
+
+	content, err := config.ReadProjectConfig()
+	if err != nil {
+		diagnostics.Error(actionsModel.ValidatorContext(configName), "failed reading file: %s", err)
+		printDiagnostics(validateOutput{Diagnostics: diagnostics})
+		return
+	}
+	allActions, err := parseActions(content)
+	if err != nil {
+		diagnostics.Error(actionsModel.ValidatorContext(configName), "failed parsing actions: %s", err)
+		printDiagnostics(validateOutput{Diagnostics: diagnostics})
+		return
+	}
+	if len(allActions) == 0 {
+		diagnostics.Error(
+			actionsModel.ValidatorContext(configName).With("actions"),
+			"actions not initialized, run tenderly actions init",
+		)
+		printDiagnostics(validateOutput{Diagnostics: diagnostics})
+		return
+	}
+
+	if actionsProjectName == "" && len(allActions) > 1 && !isInputTerminal() {
+		diagnostics.Error(
+			actionsModel.NewValidatorContext("actions"),
+			"found %d projects, select one with --project",
+			len(allActions),
+		)
+		diagnostics.Locate(configNodes())
+		printDiagnostics(validateOutput{Diagnostics: diagnostics, Annotations: diagnostics.Annotations()})
+		return
+	}
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+
+	diagnostics = validateProjectOffline(projectSlug, actions)
+	diagnostics.Locate(configNodes())
+	printDiagnostics(validateOutput{
+		Project:     projectSlug,
+		Diagnostics: diagnostics,
+		Annotations: diagnostics.Annotations(),
+	})
+}
+
+// validateProjectOffline runs every check of the build which doesn't need Tenderly API, npm registry or compilation.
+// Unlike build, it doesn't stop on first error.
+func validateProjectOffline(
+	projectSlug string,
+	actions *actionsModel.ProjectActions,
+) (diagnostics actionsModel.Diagnostics) {
+	ctx := actionsModel.ValidatorContext("actions").With(projectSlug)
+
+	if !actionsModel.IsRuntimeSupported(actions.Runtime) {
+		diagnostics.Error(
+			ctx.With("runtime"),
+			"runtime %s is not supported, supported values {%s}",
+			actions.Runtime, strings.Join(actionsModel.SupportedRuntimes, ","),
+		)
+	}
+	if !util.ExistDir(actions.Sources) {
+		diagnostics.Error(ctx.With("sources"), "directory %s not found", actions.Sources)
+		return diagnostics
+	}
+
+	outDir := actions.Sources
+	sourcesDir := actions.Sources
+	tsConfigExists := util.TsConfigExists(actions.Sources)
+	tsConfigCtx := actionsModel.ValidatorContext(filepath.Join(actions.Sources, typescript.TsConfigFile))
+	if tsConfigExists {
+		tsconfig, err := typescript.LoadTsConfig(actions.Sources)
+		if err != nil {
+			diagnostics.Error(tsConfigCtx, "failed parsing: %s", err)
+		} else if tsconfig.CompilerOptions.OutDir == nil {
+			diagnostics.Error(tsConfigCtx.With("compilerOptions").With("outDir"), "must be set")
+		} else {
+			outDir = filepath.Join(actions.Sources, *tsconfig.CompilerOptions.OutDir)
+			if tsconfig.CompilerOptions.RootDir != nil && *tsconfig.CompilerOptions.RootDir != "" {
+				sourcesDir = filepath.Join(actions.Sources, *tsconfig.CompilerOptions.RootDir)
+			}
+		}
+	}
+	built := util.ExistDir(outDir)
+	if !built {
+		diagnostics.Info(
+			ctx.With("sources"),
+			"%s not built, compiled files and zip size are not checked",
+			outDir,
+		)
+	}
+
+	abis := make(actionsModel.ContractABIs)
+	var err error
+	if specsUseABIs(actions) {
+		err = addLocalContractABIs(abis)
+	}
+	if err != nil {
+		diagnostics.Info(
+			actionsModel.ValidatorContext(configFileName()).With(config.Provider),
+			"contract abis not loaded, triggers are not checked against them: %s",
+			err,
+		)
+	}
+
+	names := make([]string, 0, len(actions.Specs))
+	for name := range actions.Specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		spec := actions.Specs[name]
+		specCtx := ctx.With("specs").With(name)
+
+		if spec.ExecutionType != actionsModel.ParallelExecutionType &&
+			spec.ExecutionType != actionsModel.SequentialExecutionType &&
+			spec.ExecutionType != "" {
+			diagnostics.Error(
+				specCtx.With("execution_type"),
+				"invalid execution type %s, supported values {%s, %s}",
+				spec.ExecutionType, actionsModel.SequentialExecutionType, actionsModel.ParallelExecutionType,
+			)
+		}
+
+		err := spec.Parse()
+		if err != nil {
+			diagnostics.Error(specCtx.With("trigger"), "failed parsing trigger: %s", err)
+		} else {
+			response := spec.TriggerParsed.Validate(specCtx.With("trigger"))
+			diagnostics.AddResponse(response)
+			if len(response.Errors) == 0 {
+				diagnostics.AddResponse(spec.TriggerParsed.ValidateSchedule(specCtx.With("trigger"), time.Now()))
+			}
+			if len(response.Errors) == 0 && len(abis) > 0 {
+				diagnostics.AddResponse(spec.TriggerParsed.ValidateABI(specCtx.With("trigger"), abis))
+			}
+			if len(response.Errors) == 0 && tsConfigExists {
+				diagnostics = append(diagnostics, validateTypesOffline(
+					specCtx.With("trigger"), name, spec.TriggerParsed, sourcesDir, abis,
+				)...)
+			}
+		}
+
+		diagnostics = append(diagnostics, validateLocatorOffline(
+			specCtx.With("function"), spec.Function, sourcesDir, outDir, tsConfigExists, built,
+		)...)
+	}
+
+	if util.PackageJSONExists(actions.Sources) {
+		diagnostics = append(diagnostics, validatePackageJSONOffline(actions)...)
+	}
+
+	ignore, err := zip.LoadIgnore(actions.Sources)
+	if err != nil {
+		diagnostics.Error(actionsModel.ValidatorContext(filepath.Join(actions.Sources, zip.IgnoreFile)), "%s", err)
+	}
+	if built {
+		diagnostics = append(diagnostics, validateZipSizeOffline(ctx.With("sources"), outDir, srcPathInZip, ignore)...)
+	}
+	if actions.RuntimeBundle {
+		diagnostics = append(diagnostics, validateBundleOffline(ctx.With("runtime_bundle"), actions)...)
+		return diagnostics
+	}
+	dependenciesDir := filepath.Join(actions.Sources, typescript.NodeModulesDir)
+	if util.ExistDir(dependenciesDir) {
+		diagnostics = append(diagnostics, validateZipSizeOffline(
+			actionsModel.ValidatorContext(dependenciesDir), dependenciesDir, nodeModulesPathInZip, ignore,
+		)...)
+	}
+
+	return diagnostics
+}
+
+// validateBundleOffline checks that esbuild is a dependency of the project, node_modules are not published.
+func validateBundleOffline(
+	ctx actionsModel.ValidatorContext,
+	actions *actionsModel.ProjectActions,
+) (diagnostics actionsModel.Diagnostics) {
+	if !util.PackageJSONExists(actions.Sources) {
+		diagnostics.Error(ctx, "requires %s with %s dependency", typescript.PackageJsonFile, typescript.EsbuildPackage)
+		return diagnostics
+	}
+	packageJSON, err := typescript.LoadPackageJson(actions.Sources)
+	if err != nil {
+		// Reported by package.json validation
+		return diagnostics
+	}
+	_, dependency := packageJSON.Dependencies[typescript.EsbuildPackage]
+	_, devDependency := packageJSON.DevDependencies[typescript.EsbuildPackage]
+	if !dependency && !devDependency {
+		diagnostics.Error(ctx, "requires %s in devDependencies of %s", typescript.EsbuildPackage, typescript.PackageJsonFile)
+	}
+	return diagnostics
+}
+
+// validateTypesOffline reports generated types of action which are missing or don't match its trigger.
+// Types are not written, they are generated by build.
+func validateTypesOffline(
+	ctx actionsModel.ValidatorContext,
+	name string,
+	trigger *actionsModel.Trigger,
+	sourcesDir string,
+	abis actionsModel.ContractABIs,
+) (diagnostics actionsModel.Diagnostics) {
+	path := filepath.Join(sourcesDir, actionsModel.TypesDir, name+".ts")
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		diagnostics.Info(ctx, "types %s not generated, run tenderly actions build", path)
+		return diagnostics
+	}
+	if string(existing) != actionsModel.GenerateTypes(name, trigger, abis) {
+		diagnostics.Info(ctx, "types %s are out of date, run tenderly actions build", path)
+	}
+	return diagnostics
+}
+
+func validateLocatorOffline(
+	ctx actionsModel.ValidatorContext,
+	locator string,
+	sourcesDir string,
+	outDir string,
+	tsConfigExists bool,
+	built bool,
+) (diagnostics actionsModel.Diagnostics) {
+	internalLocator, err := actionsModel.NewInternalLocator(locator)
+	if err != nil {
+		diagnostics.Error(ctx, "invalid locator format %s", locator)
+		return diagnostics
+	}
+
+	var filePath string
+	for _, ext := range possibleFileExtensions {
+		path := filepath.Join(sourcesDir, fmt.Sprintf("%s.%s", internalLocator.Path, ext))
+		if util.ExistFile(path) {
+			filePath = path
+			break
+		}
+	}
+	if filePath == "" {
+		diagnostics.Error(
+			ctx, "invalid locator %s, file %s.{%s} not found",
+			locator, filepath.Join(sourcesDir, internalLocator.Path), strings.Join(possibleFileExtensions, ","),
+		)
+		return diagnostics
+	}
+	if util.IsFileTs(filePath) && !tsConfigExists {
+		diagnostics.Error(ctx, "file %s is a typescript file but there is no typescript config file", filePath)
+		return diagnostics
+	}
+
+	compiledPath := filepath.Join(outDir, fmt.Sprintf("%s.js", internalLocator.Path))
+	if built && !util.ExistFile(compiledPath) {
+		diagnostics.Error(
+			ctx, "compiled file %s not found, make sure imported files are inside sources directory", compiledPath,
+		)
+	}
+	return diagnostics
+}
+
+func validatePackageJSONOffline(actions *actionsModel.ProjectActions) (diagnostics actionsModel.Diagnostics) {
+	ctx := actionsModel.ValidatorContext(filepath.Join(actions.Sources, typescript.PackageJsonFile))
+
+	packageJSON, err := typescript.LoadPackageJson(actions.Sources)
+	if err != nil {
+		diagnostics.Error(ctx, "failed parsing: %s", err)
+		return diagnostics
+	}
+
+	validator := packagejson.NewValidator(actions.Runtime)
+	resolve := installedPackageVersion(
+		filepath.Join(actions.Sources, typescript.NodeModulesDir),
+		mustGetPackageManager(actions.Sources),
+	)
+	dependencies := map[string]map[string]string{
+		"dependencies":    packageJSON.Dependencies,
+		"devDependencies": packageJSON.DevDependencies,
+	}
+	for _, field := range []string{"dependencies", "devDependencies"} {
+		// Validated one by one, so a package which isn't installed doesn't hide errors of other packages
+		for name, version := range dependencies[field] {
+			result, err := validator.ValidateWith(map[string]string{name: version}, resolve)
+			if err != nil {
+				diagnostics.Info(ctx.With(field).With(name), "not checked: %s", err)
+				continue
+			}
+			for _, e := range result.Errors {
+				diagnostics.Error(
+					ctx.With(field).With(name),
+					"version %s (installed %s) doesn't satisfy %s",
+					e.PackageJsonVersion, e.VersionToBeInstalled, e.Constraint,
+				)
+			}
+		}
+	}
+	return diagnostics
+}
+
+// installedPackageVersion resolves versions from node_modules instead of npm registry.
+func installedPackageVersion(
+	nodeModulesDir string,
+	packageManager typescript.PackageManager,
+) packagejson.VersionResolver {
+	return func(name string, version string) (string, error) {
+		path := filepath.Join(nodeModulesDir, name, typescript.PackageJsonFile)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf(
+				"package is not installed, run %s install to validate its version offline", packageManager.Name,
+			)
+		}
+
+		var installed struct {
+			Version string `json:"version"`
+		}
+		err = json.Unmarshal(content, &installed)
+		if err != nil {
+			return "", fmt.Errorf("failed parsing %s: %s", path, err)
+		}
+		return installed.Version, nil
+	}
+}
+
+func validateZipSizeOffline(
+	ctx actionsModel.ValidatorContext,
+	dirPath string,
+	insidePath string,
+	ignore *zip.Ignore,
+) (diagnostics actionsModel.Diagnostics) {
+	_, content, err := zip.Zip(dirPath, insidePath, ignore)
+	if err != nil {
+		diagnostics.Error(ctx, "zip directory %s failed: %s", dirPath, err)
+		return diagnostics
+	}
+	if len(content) > zipLimitBytes {
+		diagnostics.Error(
+			ctx, "zipped %s is %dMB, the maximum size limit is %dMB",
+			dirPath, len(content)/1024/1024, zipLimitBytes/1024/1024,
+		)
+	}
+	return diagnostics
+}
+
+// printDiagnostics prints diagnostics and exits with non-zero code if any of them is an error.
+func printDiagnostics(output validateOutput) {
+	output.Valid = !output.Diagnostics.HasErrors()
+
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(output)
+	} else {
+		for _, diagnostic := range output.Diagnostics {
+			if diagnostic.Severity == actionsModel.DiagnosticError {
+				logrus.Info(commands.Colorizer.Red(diagnostic.String()))
+			} else {
+				logrus.Info(commands.Colorizer.Blue(diagnostic.String()))
+			}
+		}
+	}
+
+	if !output.Valid {
+		if !commands.IsJSONOutput() {
+			logrus.Error(commands.Colorizer.Bold(commands.Colorizer.Red("\nFound errors when validating actions")))
+		}
+		os.Exit(1)
+	}
+	if !commands.IsJSONOutput() {
+		logrus.Info(commands.Colorizer.Green("\nValidation completed."))
+	}
+}
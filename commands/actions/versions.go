@@ -0,0 +1,151 @@
+package actions
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/rest"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+var rollbackTo string
+
+func init() {
+	rollbackCmd.PersistentFlags().StringVar(&rollbackTo, "to", "", "Version index or version id to deploy.")
+	_ = rollbackCmd.MarkPersistentFlagRequired("to")
+
+	actionsCmd.AddCommand(versionsCmd)
+	actionsCmd.AddCommand(rollbackCmd)
+}
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions <action-name>",
+	Short: "List published versions of action",
+	Args:  cobra.ExactArgs(1),
+	Run:   versionsFunc,
+}
+
+var rollbackCmd = &cobra.Command{
+	Use:   "rollback <action-name>",
+	Short: "Deploy previously published version of action",
+	Long:  "Deploys older version of action without rebuilding it. Use tenderly actions versions to find the version.",
+	Args:  cobra.ExactArgs(1),
+	Run:   rollbackFunc,
+}
+
+func versionsFunc(cmd *cobra.Command, args []string) {
+	action := mustGetActionForVersions(args[0])
+	versions := mustGetVersions(r, projectSlug, action.Id)
+
+	if commands.IsJSONOutput() {
+		commands.OutputJSON(versions)
+		return
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf("Versions of action %s:\n", commands.Colorizer.Bold(action.Name)))
+	for _, version := range versions {
+		active := " "
+		if version.Id == action.Version.Id {
+			active = commands.Colorizer.Green("*").String()
+		}
+		commitish := "-"
+		if version.Commitish != nil {
+			commitish = *version.Commitish
+		}
+		logrus.Info(commands.Colorizer.Sprintf(
+			"%s %s  %s  %s  commitish: %s  deploy requested: %t",
+			active,
+			commands.Colorizer.Bold(version.Index),
+			commands.Colorizer.Faint(version.Id),
+			time.Time(version.CreatedAt).Format(time.RFC3339),
+			commitish,
+			version.DeployRequested,
+		))
+		if version.DeployError != nil {
+			logrus.Info(commands.Colorizer.Sprintf("    deploy error: %s", commands.Colorizer.Red(*version.DeployError)))
+		}
+	}
+}
+
+func rollbackFunc(cmd *cobra.Command, args []string) {
+	action := mustGetActionForVersions(args[0])
+	versions := mustGetVersions(r, projectSlug, action.Id)
+
+	version := findVersion(versions, rollbackTo)
+	if version == nil {
+		logrus.Error(commands.Colorizer.Sprintf(
+			"Version %s not found for action %s. Run %s to list versions.",
+			commands.Colorizer.Bold(commands.Colorizer.Red(rollbackTo)),
+			commands.Colorizer.Bold(action.Name),
+			commands.Colorizer.Bold(commands.Colorizer.Green("tenderly actions versions "+action.Name)),
+		))
+		os.Exit(1)
+	}
+	if version.Id == action.Version.Id {
+		logrus.Info(commands.Colorizer.Sprintf(
+			"Version %d is already active for action %s.",
+			commands.Colorizer.Bold(version.Index),
+			commands.Colorizer.Bold(action.Name),
+		))
+		return
+	}
+
+	_, err := r.Actions.Deploy(generatedActions.DeployRequest{VersionId: version.Id}, projectSlug, action.Id)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to deploy version: %s",
+			userError.NewUserError(
+				err,
+				commands.Colorizer.Sprintf("Failed to deploy version %d: %s", version.Index, err.Error()),
+			),
+		)
+		os.Exit(1)
+	}
+
+	logrus.Info(commands.Colorizer.Sprintf(
+		"Deployed version %s of action %s.",
+		commands.Colorizer.Bold(commands.Colorizer.Green(version.Index)),
+		commands.Colorizer.Bold(action.Name),
+	))
+}
+
+func mustGetActionForVersions(actionName string) generatedActions.Action {
+	mustInitRemoteProject()
+	return mustGetRemoteAction(r, projectSlug, actionName)
+}
+
+// mustGetVersions returns versions of action, newest first.
+func mustGetVersions(r *rest.Rest, projectSlug string, actionID string) []generatedActions.Version {
+	response, err := r.Actions.GetVersions(projectSlug, actionID)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to get versions: %s",
+			userError.NewUserError(err, "Failed to get action versions."),
+		)
+		os.Exit(1)
+	}
+
+	versions := response.Versions
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Index > versions[j].Index
+	})
+	return versions
+}
+
+// findVersion matches version by index or id.
+func findVersion(versions []generatedActions.Version, indexOrID string) *generatedActions.Version {
+	index, err := strconv.Atoi(indexOrID)
+	for i := range versions {
+		if versions[i].Id == indexOrID || (err == nil && versions[i].Index == index) {
+			return &versions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+package actions
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+
+	"github.com/tenderly/tenderly-cli/commands"
+	"github.com/tenderly/tenderly-cli/commands/util"
+	actionsModel "github.com/tenderly/tenderly-cli/model/actions"
+	"github.com/tenderly/tenderly-cli/typescript"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+// Editors usually write a file in several steps, wait for them to finish before rebuilding.
+const watchDebounce = 300 * time.Millisecond
+
+// Written by build steps themselves, changes to them must not trigger another build.
+var watchIgnoredNames = map[string]bool{
+	typescript.NodeModulesDir:      true,
+	".git":                         true,
+	typescript.PackageJsonLockFile: true,
+	typescript.YarnLockFile:        true,
+	typescript.PnpmLockFile:        true,
+	actionsModel.TypesDir:          true,
+}
+
+var buildWatch bool
+var buildLocalOnly bool
+var watchRunAction string
+var watchPayloadFile string
+
+func init() {
+	buildCmd.PersistentFlags().BoolVar(
+		&buildWatch, "watch", false,
+		"Rebuild and revalidate actions whenever sources or tenderly.yaml change.",
+	)
+	buildCmd.PersistentFlags().StringVar(
+		&watchRunAction, "run", "",
+		"Name of the action to run locally after every successful build in watch mode.",
+	)
+	buildCmd.PersistentFlags().StringVar(
+		&watchPayloadFile, "payload", "",
+		"Path to the JSON event payload used with --run. If not provided, a synthetic event is generated.",
+	)
+	// Watch mode builds in a child process with this flag, since build steps exit on first error
+	buildCmd.PersistentFlags().BoolVar(&buildLocalOnly, "local", false, "Build without contacting Tenderly.")
+	_ = buildCmd.PersistentFlags().MarkHidden("local")
+}
+
+// watchFunc rebuilds actions in a child process on every change of sources or project config, until interrupted.
+func watchFunc() {
+	allActions := MustGetActions()
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		mustFailWatch(err)
+	}
+	defer watcher.Close()
+
+	ignoredDirs := watchIgnoredDirs(actions)
+	configPath := mustAbs(configFileName())
+	sourcesPath := mustAbs(actions.Sources)
+
+	// Config file is watched through its directory, editors often replace the file instead of writing to it
+	err = watcher.Add(filepath.Dir(configPath))
+	if err != nil {
+		mustFailWatch(err)
+	}
+	err = addWatchDirs(watcher, sourcesPath, ignoredDirs)
+	if err != nil {
+		mustFailWatch(err)
+	}
+
+	runWatchBuild()
+
+	var pending <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			path := mustAbs(event.Name)
+			if isWatchIgnored(path, ignoredDirs) || event.Op == fsnotify.Chmod {
+				continue
+			}
+			if path != configPath && !isSubPath(sourcesPath, path) {
+				continue
+			}
+			if event.Op&fsnotify.Create != 0 && util.ExistDir(path) {
+				err = addWatchDirs(watcher, path, ignoredDirs)
+				if err != nil {
+					logrus.Debugf("failed watching directory %s: %s", path, err)
+				}
+			}
+			logrus.Debugf("changed %s", event.Name)
+			pending = time.After(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			logrus.Debugf("watcher error: %s", err)
+		case <-pending:
+			pending = nil
+			runWatchBuild()
+		}
+	}
+}
+
+// buildLocalFunc runs local build steps and optionally the action, without login. Used for watch mode iterations.
+func buildLocalFunc() {
+	allActions := MustGetActions()
+	projectSlug = chooseLocalProject(allActions)
+	actions = mustGetProjectActions(allActions, projectSlug)
+	mustApplyEnvironment(actions)
+	mustFilterActions(actions)
+
+	var spec *actionsModel.ActionSpec
+	if watchRunAction != "" {
+		spec = mustGetActionSpec(actions, projectSlug, watchRunAction)
+	}
+
+	mustBuildLocal(actions, loadContractABIs(nil, "", actions))
+	logrus.Info(commands.Colorizer.Green("\nBuild completed."))
+
+	if spec != nil {
+		mustRunAction(watchRunAction, spec, watchPayloadFile, "")
+	}
+}
+
+func runWatchBuild() {
+	logrus.Info(commands.Colorizer.Faint(fmt.Sprintf("\n[%s] Building...", time.Now().Format("15:04:05"))))
+
+	executable, err := os.Executable()
+	if err != nil {
+		mustFailWatch(err)
+	}
+	cmd := exec.Command(executable, watchChildArgs(os.Args[1:], projectSlug, environmentName)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+	if err != nil {
+		logrus.Info(commands.Colorizer.Red("\nFailed. Waiting for changes..."))
+		return
+	}
+	logrus.Info(commands.Colorizer.Green("\nWaiting for changes..."))
+}
+
+// watchChildArgs replaces --watch with --local, keeping every other flag of the current invocation.
+// Project and environment selected by watch are passed explicitly, so the child doesn't prompt for them again.
+func watchChildArgs(args []string, projectSlug string, environmentName string) []string {
+	childArgs := make([]string, 0, len(args)+5)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--watch" || strings.HasPrefix(arg, "--watch=") {
+			continue
+		}
+		if arg == "--project" || arg == "--env" {
+			// Value is the next argument
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, "--project=") || strings.HasPrefix(arg, "--env=") {
+			continue
+		}
+		childArgs = append(childArgs, arg)
+	}
+
+	childArgs = append(childArgs, "--local", "--project", projectSlug)
+	if environmentName != "" {
+		childArgs = append(childArgs, "--env", environmentName)
+	}
+	return childArgs
+}
+
+// watchIgnoredDirs returns absolute paths of directories written by build, e.g. typescript out dir.
+func watchIgnoredDirs(actions *actionsModel.ProjectActions) []string {
+	var dirs []string
+	if util.TsConfigExists(actions.Sources) {
+		tsconfig := util.MustLoadTsConfig(actions.Sources)
+		if tsconfig.CompilerOptions.OutDir != nil && *tsconfig.CompilerOptions.OutDir != "" {
+			dirs = append(dirs, mustAbs(filepath.Join(actions.Sources, *tsconfig.CompilerOptions.OutDir)))
+		}
+	}
+	return dirs
+}
+
+func addWatchDirs(watcher *fsnotify.Watcher, root string, ignoredDirs []string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != root && isWatchIgnored(path, ignoredDirs) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+func isWatchIgnored(path string, ignoredDirs []string) bool {
+	for _, dir := range ignoredDirs {
+		if isSubPath(dir, path) {
+			return true
+		}
+	}
+	for _, part := range strings.Split(path, string(filepath.Separator)) {
+		if watchIgnoredNames[part] {
+			return true
+		}
+	}
+	return false
+}
+
+func isSubPath(dir string, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func mustAbs(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		mustFailWatch(err)
+	}
+	return abs
+}
+
+func mustFailWatch(err error) {
+	userError.LogErrorf(
+		"watch failed: %s",
+		userError.NewUserError(err, commands.Colorizer.Sprintf("Failed watching for changes: %s", err.Error())),
+	)
+	os.Exit(1)
+}
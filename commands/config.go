@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tenderly/tenderly-cli/config"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+	RootCmd.AddCommand(configCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Project configuration tools",
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print JSON Schema of tenderly.yaml",
+	Long: "Prints JSON Schema of tenderly.yaml, including actions triggers and node extensions. " +
+		"Save it to a file and reference it from the editor to get autocompletion and validation, e.g. " +
+		"add '# yaml-language-server: $schema=./tenderly.schema.json' at the top of tenderly.yaml in VS Code.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		content, err := json.MarshalIndent(config.ProjectSchema(), "", "  ")
+		if err != nil {
+			userError.LogErrorf("failed encoding schema: %s", userError.NewUserError(err, "Failed encoding schema."))
+			os.Exit(1)
+		}
+		fmt.Println(string(content))
+	},
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
@@ -90,6 +91,24 @@ func initLog() {
 	}
 }
 
+// IsJSONOutput returns true if machine readable output is requested with --output json.
+func IsJSONOutput() bool {
+	return outputMode == "json"
+}
+
+// OutputJSON prints value as a single JSON line on stdout, bypassing the log formatter.
+func OutputJSON(value interface{}) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		userError.LogErrorf("failed to marshal output: %s", userError.NewUserError(
+			err,
+			"Failed to write JSON output.",
+		))
+		os.Exit(1)
+	}
+	fmt.Println(string(content))
+}
+
 func printHelp() {
 	RootCmd.Execute()
 	os.Exit(0)
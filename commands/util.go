@@ -168,9 +168,6 @@ func InitProvider() {
 	openZeppelinPath := filepath.Join(config.ProjectDirectory, providers.OpenzeppelinConfigFile)
 	oldTrufflePath := filepath.Join(config.ProjectDirectory, providers.OldTruffleConfigFile)
 	buidlerPath := filepath.Join(config.ProjectDirectory, providers.BuidlerConfigFile)
-	hardhatPath := filepath.Join(config.ProjectDirectory, providers.HardhatConfigFile)
-	hardhatPathTs := filepath.Join(config.ProjectDirectory, providers.HardhatConfigFileTs)
-	browniePath := filepath.Join(config.ProjectDirectory, providers.BrownieConfigFile)
 
 	var provider providers.DeploymentProviderName
 
@@ -205,14 +202,27 @@ func InitProvider() {
 		WriteProjectConfig()
 	}
 
+	DeploymentProvider = DetectProvider(provider)
+}
+
+// DetectProvider returns deployment provider with name whose config file exists in project directory,
+// or the first one found if name is empty. Returns nil if none is found. It doesn't prompt or change config.
+func DetectProvider(provider providers.DeploymentProviderName) providers.DeploymentProvider {
+	trufflePath := filepath.Join(config.ProjectDirectory, providers.NewTruffleConfigFile)
+	openZeppelinPath := filepath.Join(config.ProjectDirectory, providers.OpenzeppelinConfigFile)
+	oldTrufflePath := filepath.Join(config.ProjectDirectory, providers.OldTruffleConfigFile)
+	buidlerPath := filepath.Join(config.ProjectDirectory, providers.BuidlerConfigFile)
+	hardhatPath := filepath.Join(config.ProjectDirectory, providers.HardhatConfigFile)
+	hardhatPathTs := filepath.Join(config.ProjectDirectory, providers.HardhatConfigFileTs)
+	browniePath := filepath.Join(config.ProjectDirectory, providers.BrownieConfigFile)
+
 	logrus.Debugf("Trying OpenZeppelin config path: %s", openZeppelinPath)
 	if provider == providers.OpenZeppelinDeploymentProvider || provider == "" {
 
 		_, err := os.Stat(openZeppelinPath)
 
 		if err == nil {
-			DeploymentProvider = openzeppelin.NewDeploymentProvider()
-			return
+			return openzeppelin.NewDeploymentProvider()
 		}
 
 		logrus.Debugf(
@@ -229,13 +239,13 @@ func InitProvider() {
 		_, err := os.Stat(buidlerPath)
 
 		if err == nil {
-			DeploymentProvider = buidler.NewDeploymentProvider()
+			deploymentProvider := buidler.NewDeploymentProvider()
 
-			if DeploymentProvider == nil {
+			if deploymentProvider == nil {
 				logrus.Error("Error initializing buidler")
 			}
 
-			return
+			return deploymentProvider
 		}
 
 		logrus.Debugf(
@@ -252,13 +262,13 @@ func InitProvider() {
 		_, err := os.Stat(hardhatPath)
 
 		if err == nil {
-			DeploymentProvider = hardhat.NewDeploymentProvider()
+			deploymentProvider := hardhat.NewDeploymentProvider()
 
-			if DeploymentProvider == nil {
+			if deploymentProvider == nil {
 				logrus.Error("Error initializing hardhat")
 			}
 
-			return
+			return deploymentProvider
 		}
 
 		logrus.Debugf(
@@ -273,13 +283,13 @@ func InitProvider() {
 		_, err := os.Stat(hardhatPathTs)
 
 		if err == nil {
-			DeploymentProvider = hardhat.NewDeploymentProvider()
+			deploymentProvider := hardhat.NewDeploymentProvider()
 
-			if DeploymentProvider == nil {
+			if deploymentProvider == nil {
 				logrus.Error("Error initializing hardhat")
 			}
 
-			return
+			return deploymentProvider
 		}
 
 		logrus.Debugf(
@@ -293,8 +303,7 @@ func InitProvider() {
 	if provider == providers.BrownieDeploymentProvider || provider == "" {
 		_, err := os.Stat(browniePath)
 		if err == nil {
-			DeploymentProvider = brownie.NewBrownieProvider()
-			return
+			return brownie.NewBrownieProvider()
 		}
 
 		logrus.Debugf(
@@ -308,8 +317,7 @@ func InitProvider() {
 	_, err := os.Stat(trufflePath)
 
 	if err == nil {
-		DeploymentProvider = truffle.NewDeploymentProvider()
-		return
+		return truffle.NewDeploymentProvider()
 	}
 
 	if !os.IsNotExist(err) {
@@ -327,14 +335,15 @@ func InitProvider() {
 	_, err = os.Stat(oldTrufflePath)
 
 	if err == nil {
-		DeploymentProvider = truffle.NewDeploymentProvider()
-		return
+		return truffle.NewDeploymentProvider()
 	}
 
 	logrus.Debugf(
 		fmt.Sprintf("unable to fetch config\n%s",
 			"Couldn't read old Truffle config file"),
 	)
+
+	return nil
 }
 
 func promptProviderSelect(deploymentProviders []providers.DeploymentProviderName) providers.DeploymentProviderName {
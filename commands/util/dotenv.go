@@ -0,0 +1,109 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/tenderly/tenderly-cli/userError"
+)
+
+// ParseDotEnv parses KEY=VALUE lines. Supports comments, optional "export" prefix, single quoted values (literal)
+// and double quoted values (with \n, \t, \" and \\ escapes). Later keys override earlier ones.
+func ParseDotEnv(content string) (map[string]string, error) {
+	ret := make(map[string]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		separator := strings.Index(line, "=")
+		if separator <= 0 {
+			return nil, errors.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+		key := strings.TrimSpace(line[:separator])
+		if strings.ContainsAny(key, " \t") {
+			return nil, errors.Errorf("line %d: key %q contains whitespace", lineNumber, key)
+		}
+
+		value, err := parseDotEnvValue(strings.TrimSpace(line[separator+1:]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		ret[key] = value
+	}
+
+	return ret, scanner.Err()
+}
+
+func parseDotEnvValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch value[0] {
+	case '\'':
+		end := strings.Index(value[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated single quote")
+		}
+		return value[1 : end+1], nil
+	case '"':
+		var builder strings.Builder
+		for i := 1; i < len(value); i++ {
+			c := value[i]
+			if c == '"' {
+				return builder.String(), nil
+			}
+			if c == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					builder.WriteByte('\n')
+				case 't':
+					builder.WriteByte('\t')
+				default:
+					builder.WriteByte(value[i])
+				}
+				continue
+			}
+			builder.WriteByte(c)
+		}
+		return "", errors.New("unterminated double quote")
+	}
+
+	// Unquoted values can have trailing comment
+	if comment := strings.Index(value, " #"); comment >= 0 {
+		value = value[:comment]
+	}
+	return strings.TrimSpace(value), nil
+}
+
+func MustLoadDotEnv(path string) map[string]string {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		userError.LogErrorf(
+			"failed to read env file: %s",
+			userError.NewUserError(err, fmt.Sprintf("Couldn't read env file at %s.", path)),
+		)
+		os.Exit(1)
+	}
+
+	ret, err := ParseDotEnv(string(content))
+	if err != nil {
+		userError.LogErrorf(
+			"failed to parse env file: %s",
+			userError.NewUserError(err, fmt.Sprintf("Couldn't parse env file at %s: %s.", path, err)),
+		)
+		os.Exit(1)
+	}
+	return ret
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+)
+
+func TestParseDotEnv(t *testing.T) {
+	content := `
+# comment
+API_KEY=abc123
+export RPC_URL = https://rpc.example.com # trailing comment
+SINGLE='literal \n value'
+DOUBLE="line\nbreak \"quoted\""
+EMPTY=
+API_KEY=override
+`
+	env, err := ParseDotEnv(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{
+		"API_KEY": "override",
+		"RPC_URL": "https://rpc.example.com",
+		"SINGLE":  `literal \n value`,
+		"DOUBLE":  "line\nbreak \"quoted\"",
+		"EMPTY":   "",
+	}
+	if len(env) != len(expected) {
+		t.Fatalf("expected %d keys, got %d: %v", len(expected), len(env), env)
+	}
+	for key, value := range expected {
+		if env[key] != value {
+			t.Errorf("expected %s=%q, got %q", key, value, env[key])
+		}
+	}
+}
+
+func TestParseDotEnvInvalid(t *testing.T) {
+	for _, content := range []string{
+		"NO_SEPARATOR",
+		"=value",
+		"MY KEY=value",
+		`UNTERMINATED="value`,
+		"UNTERMINATED='value",
+	} {
+		if _, err := ParseDotEnv(content); err == nil {
+			t.Errorf("expected error for %q", content)
+		}
+	}
+}
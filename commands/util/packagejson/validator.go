@@ -42,7 +42,15 @@ type ValidationResult struct {
 	Errors  []*ValidationError
 }
 
+// VersionResolver returns version of package which will be installed for version range from package.json.
+type VersionResolver func(name string, version string) (string, error)
+
 func (dv *Validator) Validate(dependencies map[string]string) (*ValidationResult, error) {
+	return dv.ValidateWith(dependencies, FindPackageVersion)
+}
+
+// ValidateWith validates dependencies resolving versions with resolve instead of npm registry.
+func (dv *Validator) ValidateWith(dependencies map[string]string, resolve VersionResolver) (*ValidationResult, error) {
 	var validationErrors []*ValidationError
 
 	for packageName, packageVersion := range dependencies {
@@ -55,7 +63,7 @@ func (dv *Validator) Validate(dependencies map[string]string) (*ValidationResult
 			continue
 		}
 
-		versionToBeInstalled, err := FindPackageVersion(packageName, packageVersion)
+		versionToBeInstalled, err := resolve(packageName, packageVersion)
 		if err != nil {
 			return nil, err
 		}
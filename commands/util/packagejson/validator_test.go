@@ -0,0 +1,35 @@
+package packagejson
+
+import (
+	"testing"
+)
+
+func TestValidateWith(t *testing.T) {
+	installed := map[string]string{
+		"axios":             "1.1.3",
+		"@tenderly/actions": "0.2.0",
+		"ethers":            "5.7.0",
+	}
+	resolve := func(name string, version string) (string, error) {
+		return installed[name], nil
+	}
+
+	result, err := NewValidator("v2").ValidateWith(map[string]string{
+		"axios":             "^1.1.0",
+		"@tenderly/actions": "^0.2.0",
+		"ethers":            "^5.7.0",
+	}, resolve)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Success {
+		t.Fatal("expected validation to fail")
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Name != "axios" {
+		t.Fatalf("expected only axios to fail, got %v", result.Errors)
+	}
+	if result.Errors[0].VersionToBeInstalled != "1.1.3" {
+		t.Errorf("expected resolved version 1.1.3, got %s", result.Errors[0].VersionToBeInstalled)
+	}
+}
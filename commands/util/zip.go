@@ -1,8 +1,6 @@
 package util
 
 import (
-	"crypto/md5"
-	"encoding/hex"
 	"fmt"
 	"os"
 
@@ -10,10 +8,10 @@ import (
 	"github.com/tenderly/tenderly-cli/zip"
 )
 
-func MustZipDir(dirPath string, insidePath string, limitBytes int) []byte {
+func MustZipDir(dirPath string, insidePath string, limitBytes int, ignore *zip.Ignore) []byte {
 	MustExistDir(dirPath)
 
-	_, content, err := zip.Zip(dirPath, insidePath)
+	_, content, err := zip.Zip(dirPath, insidePath, ignore)
 	if err != nil {
 		userError.LogErrorf("zip directory failed: %s",
 			userError.NewUserError(
@@ -31,9 +29,12 @@ func MustZipDir(dirPath string, insidePath string, limitBytes int) []byte {
 			"zip file exceeds the maximum file-size",
 			userError.NewUserError(err, fmt.Sprintf(
 				"Zip file exceeds the maximum file-size.\n"+
-					"The maximum size limit for sources / dependencies is %dMB zipped, got %dMB.",
+					"The maximum size limit for sources / dependencies is %dMB zipped, got %dMB.\n"+
+					"Run tenderly actions package --analyze to find the largest files and packages, "+
+					"and exclude the ones not needed at runtime with %s.",
 				limitBytes/1024/1024,
 				len(content)/1024/1024,
+				zip.IgnoreFile,
 			)),
 		)
 		os.Exit(1)
@@ -42,20 +43,31 @@ func MustZipDir(dirPath string, insidePath string, limitBytes int) []byte {
 	return content
 }
 
-func MustZipAndHashDir(dirPath string, insidePath string, limitBytes int) ([]byte, string) {
-	zipped := MustZipDir(dirPath, insidePath, limitBytes)
+// MustZipAndHashDir returns zip and manifest of its files, manifest hash is used as version of zipped files.
+func MustZipAndHashDir(dirPath string, insidePath string, limitBytes int, ignore *zip.Ignore) ([]byte, *zip.Manifest) {
+	zipped := MustZipDir(dirPath, insidePath, limitBytes, ignore)
 
-	hasher := md5.New()
-	hasher.Write(zipped)
-	hash := hex.EncodeToString(hasher.Sum(nil))
+	manifest, err := zip.NewManifest(zipped)
+	if err != nil {
+		userError.LogErrorf("hash zip failed: %s",
+			userError.NewUserError(
+				err,
+				fmt.Sprintf("Hashing zipped directory %s failed. Please run this command with the \"--debug\" flag and send the logs to our customer support.",
+					dirPath,
+				),
+			),
+		)
+		os.Exit(1)
+	}
 
-	return zipped, hash
+	return zipped, manifest
 }
 
-func ZipAndHashDir(dirPath, insidePath string, limitBytes int) ([]byte, string) {
+// ZipAndHashDir returns nil zip and manifest if directory doesn't exist.
+func ZipAndHashDir(dirPath, insidePath string, limitBytes int, ignore *zip.Ignore) ([]byte, *zip.Manifest) {
 	if !ExistDir(dirPath) {
-		return nil, ""
+		return nil, nil
 	}
 
-	return MustZipAndHashDir(dirPath, insidePath, limitBytes)
+	return MustZipAndHashDir(dirPath, insidePath, limitBytes, ignore)
 }
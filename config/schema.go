@@ -0,0 +1,47 @@
+package config
+
+import (
+	"github.com/tenderly/tenderly-cli/jsonschema"
+	"github.com/tenderly/tenderly-cli/model/actions"
+	extensionsModel "github.com/tenderly/tenderly-cli/model/extensions"
+)
+
+// ProjectSchema returns JSON Schema of project config file (tenderly.yaml).
+func ProjectSchema() *jsonschema.Schema {
+	g := jsonschema.NewGenerator()
+
+	networks := jsonschema.ArrayOf(&jsonschema.Schema{Type: []string{"string", "integer"}})
+	networks.Description = "Network ids of contracts pushed to project."
+
+	schema := &jsonschema.Schema{
+		Version: jsonschema.Version,
+		Title:   "Tenderly project configuration",
+		Type:    "object",
+		Properties: map[string]*jsonschema.Schema{
+			AccountID:        jsonschema.String(),
+			ProjectSlug:      jsonschema.String(),
+			Provider:         jsonschema.String(),
+			OrganizationName: jsonschema.String(),
+			Projects: {
+				Description: "Projects keyed by account/project slug.",
+				Type:        "object",
+				AdditionalProperties: &jsonschema.Schema{
+					Type:       "object",
+					Properties: map[string]*jsonschema.Schema{"networks": networks},
+				},
+			},
+			Actions: {
+				Description:          "Web3 Actions keyed by account/project slug.",
+				Type:                 "object",
+				AdditionalProperties: g.For(actions.ProjectActions{}),
+			},
+			Extensions: {
+				Description:          "Node Extensions keyed by account/project slug.",
+				Type:                 "object",
+				AdditionalProperties: g.For(extensionsModel.ConfigProjectExtensions{}),
+			},
+		},
+	}
+	schema.Definitions = g.Definitions()
+	return schema
+}
@@ -7,12 +7,14 @@ require (
 	github.com/briandowns/spinner v1.6.1
 	github.com/ethereum/go-ethereum v1.10.26
 	github.com/flynn/json5 v0.0.0-20160717195620-7620272ed633
+	github.com/fsnotify/fsnotify v1.5.1
 	github.com/ghodss/yaml v1.0.0
 	github.com/go-git/go-git/v5 v5.4.2
 	github.com/google/go-github/v37 v37.0.0
 	github.com/hashicorp/go-version v1.2.0
 	github.com/logrusorgru/aurora v0.0.0-20190803045625-94edacc10f9b
 	github.com/manifoldco/promptui v0.3.0
+	github.com/mattn/go-isatty v0.0.14
 	github.com/palantir/pkg/datetime v1.0.1
 	github.com/palantir/pkg/safejson v1.0.1
 	github.com/palantir/pkg/safeyaml v1.0.1
@@ -34,7 +36,6 @@ require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/emirpasic/gods v1.12.0 // indirect
 	github.com/fatih/color v1.13.0 // indirect
-	github.com/fsnotify/fsnotify v1.5.1 // indirect
 	github.com/go-git/gcfg v1.5.0 // indirect
 	github.com/go-git/go-billy/v5 v5.3.1 // indirect
 	github.com/google/go-querystring v1.0.0 // indirect
@@ -48,7 +49,6 @@ require (
 	github.com/lunixbochs/vtclean v1.0.0 // indirect
 	github.com/magiconair/properties v1.8.5 // indirect
 	github.com/mattn/go-colorable v0.1.12 // indirect
-	github.com/mattn/go-isatty v0.0.14 // indirect
 	github.com/mitchellh/go-homedir v1.1.0 // indirect
 	github.com/mitchellh/mapstructure v1.4.3 // indirect
 	github.com/palantir/pkg v1.0.1 // indirect
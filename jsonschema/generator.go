@@ -0,0 +1,144 @@
+package jsonschema
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+var (
+	describerType = reflect.TypeOf((*Describer)(nil)).Elem()
+	extenderType  = reflect.TypeOf((*Extender)(nil)).Elem()
+)
+
+// Generator derives schemas from Go types. Named structs are collected into definitions and referenced,
+// so every type is described once.
+type Generator struct {
+	definitions map[string]*Schema
+}
+
+func NewGenerator() *Generator {
+	return &Generator{
+		definitions: make(map[string]*Schema),
+	}
+}
+
+// Definitions returns every named struct described so far.
+func (g *Generator) Definitions() map[string]*Schema {
+	return g.definitions
+}
+
+// For returns schema of the type of value.
+func (g *Generator) For(value interface{}) *Schema {
+	return g.Reflect(reflect.TypeOf(value))
+}
+
+// Reflect returns schema of type t. Key of struct field is taken from yaml tag, then json tag, then field name.
+// Fields which aren't pointers, slices, maps or bools and aren't marked omitempty are required.
+func (g *Generator) Reflect(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if reflect.PtrTo(t).Implements(describerType) {
+		return reflect.New(t).Interface().(Describer).JSONSchema(g)
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return String()
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Integer()
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return ArrayOf(g.Reflect(t.Elem()))
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.Reflect(t.Elem())}
+	case reflect.Struct:
+		return g.reflectStruct(t)
+	}
+	return &Schema{}
+}
+
+func (g *Generator) reflectStruct(t reflect.Type) *Schema {
+	name := t.Name()
+	if name != "" {
+		if _, exists := g.definitions[name]; exists {
+			return ref(name)
+		}
+		// Placeholder, so recursive types reference themselves instead of recursing forever
+		g.definitions[name] = &Schema{}
+	}
+
+	s := &Schema{
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		key, omitEmpty := fieldKey(field)
+		if key == "-" {
+			continue
+		}
+
+		s.Properties[key] = g.Reflect(field.Type)
+		if !omitEmpty && isRequiredKind(field.Type.Kind()) {
+			s.Required = append(s.Required, key)
+		}
+	}
+
+	if reflect.PtrTo(t).Implements(extenderType) {
+		reflect.New(t).Interface().(Extender).JSONSchemaExtend(s)
+	}
+
+	if name == "" {
+		return s
+	}
+	*g.definitions[name] = *s
+	return ref(name)
+}
+
+func ref(name string) *Schema {
+	return &Schema{Ref: "#/definitions/" + name}
+}
+
+func fieldKey(field reflect.StructField) (key string, omitEmpty bool) {
+	for _, tagName := range []string{"yaml", "json"} {
+		tag, exists := field.Tag.Lookup(tagName)
+		if !exists {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		for _, option := range parts[1:] {
+			if option == "omitempty" {
+				omitEmpty = true
+			}
+		}
+		if key == "" {
+			key = parts[0]
+		}
+	}
+
+	if key == "" {
+		runes := []rune(field.Name)
+		runes[0] = unicode.ToLower(runes[0])
+		key = string(runes)
+	}
+	return key, omitEmpty
+}
+
+func isRequiredKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Bool, reflect.Interface:
+		return false
+	}
+	return true
+}
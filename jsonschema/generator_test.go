@@ -0,0 +1,73 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testNode struct {
+	Name     string      `yaml:"name"`
+	Comment  string      `yaml:"comment,omitempty"`
+	Children []*testNode `yaml:"children"`
+	Value    *testValue  `json:"value"`
+	Ignored  string      `yaml:"-"`
+	Enabled  bool
+}
+
+type testValue struct {
+	Value string
+}
+
+func (v *testValue) JSONSchema(g *Generator) *Schema {
+	return SingleOrList(String())
+}
+
+func (n *testNode) JSONSchemaExtend(s *Schema) {
+	s.AllOf = append(s.AllOf, ForbidTogether("comment", "value"))
+}
+
+func TestReflectStruct(t *testing.T) {
+	g := NewGenerator()
+	s := g.For(testNode{})
+
+	if s.Ref != "#/definitions/testNode" {
+		t.Fatalf("expected reference to testNode, got %q", s.Ref)
+	}
+	node := g.Definitions()["testNode"]
+	if node == nil {
+		t.Fatal("expected testNode definition")
+	}
+
+	var keys []string
+	for key := range node.Properties {
+		keys = append(keys, key)
+	}
+	for _, key := range []string{"name", "comment", "children", "value", "enabled"} {
+		if node.Properties[key] == nil {
+			t.Errorf("expected property %s, got %v", key, keys)
+		}
+	}
+	if node.Properties["ignored"] != nil || node.Properties["-"] != nil {
+		t.Error("field with yaml:\"-\" must be skipped")
+	}
+
+	if !reflect.DeepEqual(node.Required, []string{"name"}) {
+		t.Errorf("expected only name to be required, got %v", node.Required)
+	}
+	if node.Properties["children"].Items.Ref != "#/definitions/testNode" {
+		t.Error("expected recursive reference for children")
+	}
+	if len(node.Properties["value"].AnyOf) != 2 {
+		t.Error("expected describer schema for value")
+	}
+	if len(node.AllOf) != 1 || node.AllOf[0].Not == nil {
+		t.Error("expected extender constraint")
+	}
+}
+
+func TestRequireOne(t *testing.T) {
+	s := RequireOne("a", "b")
+	if len(s.OneOf) != 2 || s.OneOf[0].Required[0] != "a" || s.OneOf[1].Required[0] != "b" {
+		t.Errorf("unexpected schema %+v", s)
+	}
+}
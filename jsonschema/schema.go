@@ -0,0 +1,90 @@
+package jsonschema
+
+// Version is JSON Schema draft used by generated schemas. Draft 7 is the latest one supported by most editors.
+const Version = "http://json-schema.org/draft-07/schema#"
+
+// Schema is subset of JSON Schema used to describe configuration files.
+type Schema struct {
+	Version     string             `json:"$schema,omitempty"`
+	ID          string             `json:"$id,omitempty"`
+	Ref         string             `json:"$ref,omitempty"`
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+
+	Type    interface{}   `json:"type,omitempty"`
+	Enum    []interface{} `json:"enum,omitempty"`
+	Const   interface{}   `json:"const,omitempty"`
+	Pattern string        `json:"pattern,omitempty"`
+	Minimum *int          `json:"minimum,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	AllOf []*Schema `json:"allOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+	If    *Schema   `json:"if,omitempty"`
+	Then  *Schema   `json:"then,omitempty"`
+}
+
+// Describer is implemented by types with custom unmarshalling, whose schema can't be derived from struct fields.
+type Describer interface {
+	JSONSchema(g *Generator) *Schema
+}
+
+// Extender is implemented by structs which add constraints to schema derived from their fields,
+// e.g. fields which are mutually exclusive.
+type Extender interface {
+	JSONSchemaExtend(s *Schema)
+}
+
+func String() *Schema {
+	return &Schema{Type: "string"}
+}
+
+func Integer() *Schema {
+	return &Schema{Type: "integer"}
+}
+
+func Enum(values ...string) *Schema {
+	s := &Schema{Type: "string"}
+	for _, value := range values {
+		s.Enum = append(s.Enum, value)
+	}
+	return s
+}
+
+func ArrayOf(items *Schema) *Schema {
+	return &Schema{Type: "array", Items: items}
+}
+
+// SingleOrList accepts either single value or non-empty list of values.
+func SingleOrList(item *Schema) *Schema {
+	list := ArrayOf(item)
+	list.MinItems = Int(1)
+	return &Schema{AnyOf: []*Schema{item, list}}
+}
+
+// RequireOne creates schema which is valid if exactly one of properties is set.
+func RequireOne(properties ...string) *Schema {
+	s := &Schema{}
+	for _, property := range properties {
+		s.OneOf = append(s.OneOf, &Schema{Required: []string{property}})
+	}
+	return s
+}
+
+// ForbidTogether creates schema which is invalid if all properties are set.
+func ForbidTogether(properties ...string) *Schema {
+	return &Schema{Not: &Schema{Required: properties}}
+}
+
+func Int(value int) *int {
+	return &value
+}
@@ -0,0 +1,319 @@
+package actions
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// ContractABIs holds parsed contract ABIs by lowercase contract address and network id.
+type ContractABIs map[string]map[string]*abi.ABI
+
+// Add parses ABI in JSON format and stores it for contract at address on network.
+func (c ContractABIs) Add(network string, address string, abiJSON []byte) error {
+	parsed, err := abi.JSON(bytes.NewReader(abiJSON))
+	if err != nil {
+		return errors.Wrapf(err, "failed parsing abi of contract %s", address)
+	}
+
+	address = strings.ToLower(address)
+	if c[address] == nil {
+		c[address] = make(map[string]*abi.ABI)
+	}
+	c[address][network] = &parsed
+	return nil
+}
+
+// Find returns ABI of contract at address on the first of networks it is found on.
+// If no networks are given, ABI from any network is returned. Returns nil if ABI is not found.
+func (c ContractABIs) Find(address string, networks []string) *abi.ABI {
+	byNetwork := c[strings.ToLower(address)]
+	if len(networks) == 0 {
+		ids := make([]string, 0, len(byNetwork))
+		for id := range byNetwork {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		networks = ids
+	}
+	for _, network := range networks {
+		if contractABI, ok := byNetwork[network]; ok {
+			return contractABI
+		}
+	}
+	return nil
+}
+
+// UsesABI reports whether trigger of spec has function or eventEmitted conditions of a contract, which are
+// checked against its ABI. Spec itself is not modified, spec with trigger that can't be parsed doesn't use ABI.
+func (a ActionSpec) UsesABI() bool {
+	err := a.Parse()
+	return err == nil && a.TriggerParsed.UsesABI()
+}
+
+func (a Trigger) UsesABI() bool {
+	if a.Transaction == nil {
+		return false
+	}
+	for _, filter := range a.Transaction.Filters {
+		if filter.UsesABI() {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesABI is checked before Validate, which sets contract of filter on function and eventEmitted values.
+func (t *TransactionFilter) UsesABI() bool {
+	if t.Function != nil {
+		for _, value := range t.Function.Values {
+			if t.Contract != nil || value.Contract != nil {
+				return true
+			}
+		}
+	}
+	if t.EventEmitted != nil {
+		for _, value := range t.EventEmitted.Values {
+			if t.Contract != nil || value.Contract != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidateABI checks names, signatures and parameter conditions of transaction trigger against contract ABIs.
+// Must be called after Validate succeeds, as Validate sets filter contract on nested fields.
+func (a Trigger) ValidateABI(ctx ValidatorContext, abis ContractABIs) (response ValidateResponse) {
+	if a.Transaction == nil {
+		return response
+	}
+	return response.Merge(a.Transaction.ValidateABI(ctx.With(TransactionType), abis))
+}
+
+func (t *TransactionTrigger) ValidateABI(ctx ValidatorContext, abis ContractABIs) (response ValidateResponse) {
+	for i, filter := range t.Filters {
+		response.Merge(filter.ValidateABI(ctx.With("filters").With(strconv.Itoa(i)), abis))
+	}
+	return response
+}
+
+func (t *TransactionFilter) ValidateABI(ctx ValidatorContext, abis ContractABIs) (response ValidateResponse) {
+	var networks []string
+	if t.Network != nil {
+		networks = t.Network.ToRequest()
+	}
+
+	if t.Function != nil {
+		for i, value := range t.Function.Values {
+			nextCtx := ctx.With("function")
+			if len(t.Function.Values) > 1 {
+				nextCtx = nextCtx.With(strconv.Itoa(i))
+			}
+			response.Merge(value.ValidateABI(nextCtx, abis, networks))
+		}
+	}
+	if t.EventEmitted != nil {
+		for i, value := range t.EventEmitted.Values {
+			nextCtx := ctx.With("eventEmitted")
+			if len(t.EventEmitted.Values) > 1 {
+				nextCtx = nextCtx.With(strconv.Itoa(i))
+			}
+			response.Merge(value.ValidateABI(nextCtx, abis, networks))
+		}
+	}
+	return response
+}
+
+func (f *FunctionValue) ValidateABI(ctx ValidatorContext, abis ContractABIs, networks []string) (response ValidateResponse) {
+	if f.Contract == nil {
+		return response
+	}
+	address := f.Contract.Address.String()
+	contractABI := abis.Find(address, networks)
+	if contractABI == nil {
+		return response.Info(ctx.With("contract"), MsgABINotFound, address, "function")
+	}
+
+	if f.Signature != nil {
+		for _, method := range contractABI.Methods {
+			if hexutil.Encode(method.ID) == f.Signature.String() {
+				return response
+			}
+		}
+		return response.Error(ctx.With("signature"), MsgSignatureNotInABI, f.Signature.String(), address)
+	}
+	if f.Name == nil {
+		return response
+	}
+
+	var names []string
+	var inputs []abi.Arguments
+	for _, method := range contractABI.Methods {
+		names = append(names, method.RawName)
+		if method.RawName == *f.Name {
+			inputs = append(inputs, method.Inputs)
+		}
+	}
+	if len(inputs) == 0 {
+		return response.Error(ctx.With("name"), MsgFunctionNotInABI, *f.Name, address, suggestion(*f.Name, names))
+	}
+	return response.Merge(validateParametersABI(ctx.With("parameters"), *f.Name, f.Parameters, inputs))
+}
+
+func (r *EventEmittedValue) ValidateABI(ctx ValidatorContext, abis ContractABIs, networks []string) (response ValidateResponse) {
+	if r.Contract == nil {
+		return response
+	}
+	address := r.Contract.Address.String()
+	contractABI := abis.Find(address, networks)
+	if contractABI == nil {
+		return response.Info(ctx.With("contract"), MsgABINotFound, address, "event")
+	}
+
+	if r.Id != nil {
+		for _, event := range contractABI.Events {
+			if strings.ToLower(event.ID.Hex()) == *r.Id {
+				return response.Merge(validateParametersABI(ctx.With("parameters"), event.RawName, r.Parameters, []abi.Arguments{event.Inputs}))
+			}
+		}
+		return response.Error(ctx.With("id"), MsgEventIdNotInABI, *r.Id, address)
+	}
+	if r.Name == nil {
+		return response
+	}
+
+	var names []string
+	var inputs []abi.Arguments
+	for _, event := range contractABI.Events {
+		names = append(names, event.RawName)
+		if event.RawName == *r.Name {
+			inputs = append(inputs, event.Inputs)
+		}
+	}
+	if len(inputs) == 0 {
+		return response.Error(ctx.With("name"), MsgEventNotInABI, *r.Name, address, suggestion(*r.Name, names))
+	}
+	return response.Merge(validateParametersABI(ctx.With("parameters"), *r.Name, r.Parameters, inputs))
+}
+
+// validateParametersABI checks that every parameter condition names an input of one of overloads
+// and that its comparison fits the input type: 'int' for integers, 'string' for everything else.
+func validateParametersABI(
+	ctx ValidatorContext, owner string, parameters []ParameterCondValue, overloads []abi.Arguments,
+) (response ValidateResponse) {
+	for i, p := range parameters {
+		paramCtx := ctx.With(strconv.Itoa(i))
+
+		var names []string
+		var types []abi.Type
+		for _, inputs := range overloads {
+			for _, input := range inputs {
+				names = append(names, input.Name)
+				if input.Name == p.Name {
+					types = append(types, input.Type)
+				}
+			}
+		}
+		if len(types) == 0 {
+			response.Error(paramCtx.With("name"), MsgParameterNotInABI, p.Name, owner, suggestion(p.Name, names))
+			continue
+		}
+
+		if p.Int != nil && !anyType(types, isIntType) {
+			response.Error(paramCtx.With("int"), MsgParameterTypeMismatch, p.Name, types[0].String(), "int")
+		}
+		if p.String != nil && !anyType(types, func(t abi.Type) bool { return !isIntType(t) }) {
+			response.Error(paramCtx.With("string"), MsgParameterTypeMismatch, p.Name, types[0].String(), "string")
+		}
+	}
+	return response
+}
+
+func isIntType(t abi.Type) bool {
+	return t.T == abi.IntTy || t.T == abi.UintTy
+}
+
+func anyType(types []abi.Type, matches func(abi.Type) bool) bool {
+	for _, t := range types {
+		if matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestion returns ", did you mean ..." listing up to three candidates closest to name, or empty string.
+func suggestion(name string, candidates []string) string {
+	maxDistance := len(name) / 3
+	if maxDistance < 2 {
+		maxDistance = 2
+	}
+
+	distances := make(map[string]int)
+	for _, candidate := range candidates {
+		if candidate == "" || candidate == name {
+			continue
+		}
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if distance <= maxDistance {
+			distances[candidate] = distance
+		}
+	}
+
+	closest := make([]string, 0, len(distances))
+	for candidate := range distances {
+		closest = append(closest, candidate)
+	}
+	sort.Slice(closest, func(i, j int) bool {
+		if distances[closest[i]] != distances[closest[j]] {
+			return distances[closest[i]] < distances[closest[j]]
+		}
+		return closest[i] < closest[j]
+	})
+	if len(closest) == 0 {
+		return ""
+	}
+	if len(closest) > 3 {
+		closest = closest[:3]
+	}
+	return fmt.Sprintf(", did you mean '%s'?", strings.Join(closest, "', '"))
+}
+
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func min3(a int, b int, c int) int {
+	m := a
+	if b < m {
+		m = b
+	}
+	if c < m {
+		m = c
+	}
+	return m
+}
@@ -0,0 +1,84 @@
+package actions_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+const erc20ABI = `[
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","inputs":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","inputs":[{"name":"spender","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Approval","inputs":[{"name":"owner","type":"address","indexed":true},{"name":"spender","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func mustERC20ABIs(t *testing.T, network string) actions.ContractABIs {
+	abis := make(actions.ContractABIs)
+	err := abis.Add(network, "0x13253c152f4D724D15D7B064DE106A739551dA5F", []byte(erc20ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return abis
+}
+
+func TestValidateABI(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_function_parameters")
+	trigger.Transaction.Filters[0].Function.Values[0].Parameters[0].Name = "to"
+
+	response := trigger.ValidateABI("test", mustERC20ABIs(t, "1"))
+	if len(response.Errors) != 0 || len(response.Infos) != 0 {
+		t.Errorf("expected no diagnostics, got %v %v", response.Errors, response.Infos)
+	}
+}
+
+func TestValidateABIInvalid(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_abi_invalid")
+
+	response := trigger.ValidateABI("test", mustERC20ABIs(t, "1"))
+	expected := []string{
+		"test.transaction.filters.0.function.0.name: function 'transfr' not found in abi of contract 0x13253c152f4d724d15d7b064de106a739551da5f, did you mean 'transfer'?",
+		"test.transaction.filters.0.function.1.parameters.0.name: parameter 'recipent' not found in inputs of 'transfer'",
+		"test.transaction.filters.0.function.1.parameters.1.int: parameter 'to' is address, 'int' condition can not be used",
+		"test.transaction.filters.0.function.1.parameters.2.string: parameter 'amount' is uint256, 'string' condition can not be used",
+		"test.transaction.filters.0.function.2.signature: signature '0x12345678' does not match any function in abi of contract 0x13253c152f4d724d15d7b064de106a739551da5f",
+		"test.transaction.filters.0.eventEmitted.0.name: event 'Transfers' not found in abi of contract 0x13253c152f4d724d15d7b064de106a739551da5f, did you mean 'Transfer'?",
+		"test.transaction.filters.0.eventEmitted.1.id: id '0x0000000000000000000000000000000000000000000000000000000000000000' does not match any event in abi of contract 0x13253c152f4d724d15d7b064de106a739551da5f",
+	}
+	if strings.Join(response.Errors, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("expected errors:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(response.Errors, "\n"))
+	}
+	if len(response.Infos) != 1 || !strings.Contains(response.Infos[0], "eventEmitted.2.contract: abi of contract 0xfc4c08972fa997c447982d634b0b48c554d92cee not found") {
+		t.Errorf("expected missing abi info, got %v", response.Infos)
+	}
+}
+
+func TestValidateABIOtherNetwork(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_abi_invalid")
+
+	response := trigger.ValidateABI("test", mustERC20ABIs(t, "42"))
+	if len(response.Errors) != 0 {
+		t.Errorf("expected no errors for abi on other network, got %v", response.Errors)
+	}
+}
+
+func TestUsesABI(t *testing.T) {
+	tests := []struct {
+		file     string
+		expected bool
+	}{
+		{"trigger_abi_invalid", true},
+		{"trigger_typegen_events", true},
+		{"trigger_function_signature", true},
+		{"trigger_block_simple", false},
+		{"trigger_webhook_simple", false},
+	}
+	for _, test := range tests {
+		trigger, _, _ := MustReadTrigger(test.file)
+		if trigger.UsesABI() != test.expected {
+			t.Errorf("%s: expected uses abi %t", test.file, test.expected)
+		}
+	}
+}
@@ -20,15 +20,46 @@ type Action struct {
 }
 
 type ProjectActions struct {
-	Runtime      string           `json:"runtime" yaml:"runtime"`
-	Sources      string           `json:"sources" yaml:"sources"`
-	Dependencies *string          `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
-	Specs        NamedActionSpecs `json:"specs" yaml:"specs"`
+	Runtime      string  `json:"runtime" yaml:"runtime"`
+	Sources      string  `json:"sources" yaml:"sources"`
+	Dependencies *string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	// RuntimeBundle bundles every action entrypoint with code it reaches, instead of publishing node_modules.
+	RuntimeBundle bool             `json:"runtime_bundle,omitempty" yaml:"runtime_bundle,omitempty"`
+	Specs         NamedActionSpecs `json:"specs" yaml:"specs"`
+	// Environments override specs, selected with --env.
+	Environments map[string]Environment `json:"environments,omitempty" yaml:"environments,omitempty"`
 }
 
 // NamedActionSpecs is a map from action name to action spec
 type NamedActionSpecs map[string]*ActionSpec
 
+// Filter returns specs named in only (all specs if only is empty) that are not named in exclude.
+// Unknown names are reported as error, so a typo doesn't silently change what gets published.
+func (s NamedActionSpecs) Filter(only []string, exclude []string) (NamedActionSpecs, error) {
+	for _, name := range append(append([]string{}, only...), exclude...) {
+		if _, exists := s[name]; !exists {
+			return nil, errors.Errorf("action %s not found", name)
+		}
+	}
+
+	excluded := make(map[string]bool)
+	for _, name := range exclude {
+		excluded[name] = true
+	}
+
+	response := make(NamedActionSpecs)
+	for name, spec := range s {
+		if len(only) > 0 && !contains(only, name) {
+			continue
+		}
+		if excluded[name] {
+			continue
+		}
+		response[name] = spec
+	}
+	return response, nil
+}
+
 func (s *ProjectActions) ToRequest(sources map[string]string) map[string]actions.ActionSpec {
 	response := make(map[string]actions.ActionSpec)
 	for name, action := range s.Specs {
@@ -123,3 +154,12 @@ func invocationTypeFromExecution(executionType string) string {
 		return ""
 	}
 }
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
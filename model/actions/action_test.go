@@ -24,3 +24,48 @@ func TestParse(t *testing.T) {
 		t.Fatal("incorrectly parsed")
 	}
 }
+
+func TestNamedActionSpecsFilter(t *testing.T) {
+	specs := actions.NamedActionSpecs{
+		"a": &actions.ActionSpec{},
+		"b": &actions.ActionSpec{},
+		"c": &actions.ActionSpec{},
+	}
+
+	tests := []struct {
+		only     []string
+		exclude  []string
+		expected []string
+	}{
+		{nil, nil, []string{"a", "b", "c"}},
+		{[]string{"a", "c"}, nil, []string{"a", "c"}},
+		{nil, []string{"b"}, []string{"a", "c"}},
+		{[]string{"a", "b"}, []string{"b"}, []string{"a"}},
+	}
+
+	for _, test := range tests {
+		filtered, err := specs.Filter(test.only, test.exclude)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(filtered) != len(test.expected) {
+			t.Errorf("only %v exclude %v: expected %v, got %d specs", test.only, test.exclude, test.expected, len(filtered))
+		}
+		for _, name := range test.expected {
+			if _, exists := filtered[name]; !exists {
+				t.Errorf("only %v exclude %v: expected %s to be selected", test.only, test.exclude, name)
+			}
+		}
+	}
+}
+
+func TestNamedActionSpecsFilterUnknown(t *testing.T) {
+	specs := actions.NamedActionSpecs{"a": &actions.ActionSpec{}}
+
+	if _, err := specs.Filter([]string{"typo"}, nil); err == nil {
+		t.Error("expected error for unknown name in only")
+	}
+	if _, err := specs.Filter(nil, []string{"typo"}); err == nil {
+		t.Error("expected error for unknown name in exclude")
+	}
+}
@@ -10,6 +10,7 @@ var (
 	RuntimeV1          = "v1"
 	RuntimeV2          = "v2"
 	SupportedRuntimes  = []string{RuntimeV1, RuntimeV2}
+	RuntimeNodeTargets = map[string]string{RuntimeV1: "node14", RuntimeV2: "node16"}
 	TriggerTypes       = []string{"periodic", "webhook", "block", "transaction", "alert"}
 	PeriodicType       = "periodic"
 	WebhookType        = "webhook"
@@ -21,19 +22,25 @@ var (
 	InvocationDirect   = "direct"
 	InvocationInternal = "internal"
 
-	Intervals      = []string{"5m", "10m", "15m", "30m", "1h", "3h", "6h", "12h", "1d"}
+	Intervals      = []string{"5m", "10m", "15m", "30m", "1h", "2h", "3h", "6h", "12h", "1d", "weekly", "monthly"}
 	IntervalToCron = map[string]string{
-		"5m":  "*/5 * * * *",
-		"10m": "*/10 * * * *",
-		"15m": "*/15 * * * *",
-		"30m": "*/30 * * * *",
-		"1h":  "0 * * * *",
-		"3h":  "0 */3 * * *",
-		"6h":  "0 */6 * * *",
-		"12h": "0 */12 * * *",
-		"1d":  "0 0 * * *",
+		"5m":      "*/5 * * * *",
+		"10m":     "*/10 * * * *",
+		"15m":     "*/15 * * * *",
+		"30m":     "*/30 * * * *",
+		"1h":      "0 * * * *",
+		"2h":      "0 */2 * * *",
+		"3h":      "0 */3 * * *",
+		"6h":      "0 */6 * * *",
+		"12h":     "0 */12 * * *",
+		"1d":      "0 0 * * *",
+		"weekly":  "0 0 * * 1",
+		"monthly": "0 0 1 * *",
 	}
 
+	// Intervals accepted by Tenderly API, others are sent only as cron
+	PublishedIntervals = []string{"5m", "10m", "15m", "30m", "1h", "3h", "6h", "12h", "1d"}
+
 	CronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 
 	AddressRegex = "^0x[0-9a-f]{40}$"
@@ -52,6 +59,8 @@ var (
 	MsgIntervalAndCronForbidden            = "both 'cron' and 'interval' is forbidden"
 	MsgIntervalNotSupported                = "interval '%s' not supported, supported intervals %s"
 	MsgCronNotSupported                    = "cron '%s' is not supported, got error %s"
+	MsgCronNeverFires                      = "cron '%s' never fires"
+	MsgCronTooFrequent                     = "cron '%s' fires every %s, more often than every %s"
 	MsgBlocksNegative                      = "blocks must be greater than 0, found %d"
 	MsgDefaultToAnyInvocation              = "invocation not set for contract, defaulting to any"
 	MsgInvocationNotSupported              = "invocation '%s' not supported, supported invocations %s"
@@ -75,4 +84,12 @@ var (
 	MsgHexValueEmpty                       = "expected non-empty hex value"
 	MsgHexValueInvalid                     = "hex value must start with 0x, got %s"
 	MsgMinFilterConstraint                 = "constraint for minimum transaction filters must be fulfilled"
+	MsgABINotFound                         = "abi of contract %s not found, %s is not checked"
+	MsgFunctionNotInABI                    = "function '%s' not found in abi of contract %s%s"
+	MsgEventNotInABI                       = "event '%s' not found in abi of contract %s%s"
+	MsgSignatureNotInABI                   = "signature '%s' does not match any function in abi of contract %s"
+	MsgEventIdNotInABI                     = "id '%s' does not match any event in abi of contract %s"
+	MsgParameterNotInABI                   = "parameter '%s' not found in inputs of '%s'%s"
+	MsgParameterTypeMismatch               = "parameter '%s' is %s, '%s' condition can not be used"
+	MsgBodyFieldTypeInvalid                = "expected typescript type like 'string', 'bigint | string' or 'string[]', or nested fields, got '%s'"
 )
@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"fmt"
+)
+
+type DiagnosticSeverity string
+
+const (
+	DiagnosticError DiagnosticSeverity = "error"
+	DiagnosticInfo  DiagnosticSeverity = "info"
+)
+
+// Diagnostic is single validation message. Path is the ValidatorContext the message is about, e.g. path in
+// tenderly.yaml or file name. Position is set once diagnostic is located in config file.
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Path     string             `json:"path"`
+	Message  string             `json:"message"`
+	Position *Position          `json:"position,omitempty"`
+
+	context ValidatorContext
+}
+
+func newDiagnostic(severity DiagnosticSeverity, c ValidatorContext, message string) Diagnostic {
+	return Diagnostic{Severity: severity, Path: c.String(), Message: message, context: c}
+}
+
+// String renders diagnostic compiler-style if its position is known, e.g. tenderly.yaml:42:7: message.
+func (d Diagnostic) String() string {
+	if d.Position != nil {
+		return fmt.Sprintf("%s:%d:%d: %s", d.Position.File, d.Position.Line, d.Position.Column, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", d.Path, d.Message)
+}
+
+// Annotation is diagnostic in the format of GitHub check run annotation.
+type Annotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	StartColumn     int    `json:"start_column"`
+	EndColumn       int    `json:"end_column"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title"`
+	Message         string `json:"message"`
+}
+
+// Annotation returns false if diagnostic has no position, GitHub can't show it in file.
+func (d Diagnostic) Annotation() (Annotation, bool) {
+	if d.Position == nil {
+		return Annotation{}, false
+	}
+
+	level := "notice"
+	if d.Severity == DiagnosticError {
+		level = "failure"
+	}
+	return Annotation{
+		Path:            d.Position.File,
+		StartLine:       d.Position.Line,
+		EndLine:         d.Position.Line,
+		StartColumn:     d.Position.Column,
+		EndColumn:       d.Position.Column,
+		AnnotationLevel: level,
+		Title:           d.Path,
+		Message:         d.Message,
+	}, true
+}
+
+type Diagnostics []Diagnostic
+
+func (d *Diagnostics) Error(c ValidatorContext, msg string, args ...interface{}) {
+	*d = append(*d, newDiagnostic(DiagnosticError, c, fmt.Sprintf(msg, args...)))
+}
+
+func (d *Diagnostics) Info(c ValidatorContext, msg string, args ...interface{}) {
+	*d = append(*d, newDiagnostic(DiagnosticInfo, c, fmt.Sprintf(msg, args...)))
+}
+
+// AddResponse adds messages of validate response with the context they are about.
+func (d *Diagnostics) AddResponse(response ValidateResponse) {
+	for _, message := range response.errors {
+		*d = append(*d, newDiagnostic(DiagnosticError, message.ctx, message.msg))
+	}
+	for _, message := range response.infos {
+		*d = append(*d, newDiagnostic(DiagnosticInfo, message.ctx, message.msg))
+	}
+}
+
+// Locate sets position of every diagnostic whose path, or its parent, is found in nodes.
+func (d Diagnostics) Locate(nodes NodeMap) {
+	for i := range d {
+		if position, found := nodes.Find(d[i].context); found {
+			d[i].Position = &position
+		}
+	}
+}
+
+func (d Diagnostics) Annotations() []Annotation {
+	var annotations []Annotation
+	for _, diagnostic := range d {
+		if annotation, ok := diagnostic.Annotation(); ok {
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations
+}
+
+func (d Diagnostics) HasErrors() bool {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == DiagnosticError {
+			return true
+		}
+	}
+	return false
+}
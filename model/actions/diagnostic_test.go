@@ -0,0 +1,60 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+func TestDiagnosticsFromResponse(t *testing.T) {
+	_, response, ok := MustReadTrigger("trigger_periodic_invalid_interval")
+	if ok {
+		t.Fatal("expected validation to fail")
+	}
+
+	var diagnostics actions.Diagnostics
+	diagnostics.AddResponse(response)
+
+	if !diagnostics.HasErrors() {
+		t.Fatal("expected errors")
+	}
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Path != "test.periodic" {
+			t.Errorf("expected path test.periodic, got %s", diagnostic.Path)
+		}
+		if diagnostic.String() != response.Errors[0] {
+			t.Errorf("expected %s, got %s", response.Errors[0], diagnostic.String())
+		}
+	}
+}
+
+func TestDiagnosticsSeverity(t *testing.T) {
+	var diagnostics actions.Diagnostics
+	diagnostics.Info(actions.NewValidatorContext("a", "b"), "info %d", 1)
+	if diagnostics.HasErrors() {
+		t.Error("info must not be reported as error")
+	}
+
+	diagnostics.Error(actions.ValidatorContext("a").With("c"), "error %s", "x")
+	if !diagnostics.HasErrors() {
+		t.Error("expected errors")
+	}
+	if diagnostics[1].Path != "a.c" || diagnostics[1].Message != "error x" {
+		t.Errorf("unexpected diagnostic %+v", diagnostics[1])
+	}
+}
+
+func TestDiagnosticsFromResponseKeepContext(t *testing.T) {
+	var response actions.ValidateResponse
+	response.Info(actions.NewValidatorContext("note: daily", "trigger"), "message: with colon")
+
+	var diagnostics actions.Diagnostics
+	diagnostics.AddResponse(response)
+
+	if diagnostics[0].Path != "note: daily.trigger" || diagnostics[0].Message != "message: with colon" {
+		t.Errorf("unexpected diagnostic %+v", diagnostics[0])
+	}
+	if diagnostics[0].String() != response.Infos[0] {
+		t.Errorf("expected %s, got %s", response.Infos[0], diagnostics[0].String())
+	}
+}
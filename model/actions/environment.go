@@ -0,0 +1,139 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Environment overrides project actions for one deployment environment, e.g. staging.
+type Environment struct {
+	// Project is Tenderly project environment is published to. Defaults to project actions are configured for.
+	Project *string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Secrets are set in project before publishing. Values can reference environment variables, e.g. $API_KEY.
+	Secrets map[string]string `json:"secrets,omitempty" yaml:"secrets,omitempty"`
+	// Specs are merged into action specs with the same name. Maps are merged recursively, lists and other values
+	// are replaced, e.g. overriding network list of block trigger replaces all networks.
+	Specs map[string]map[string]interface{} `json:"specs,omitempty" yaml:"specs,omitempty"`
+}
+
+// ApplyEnvironment merges spec overrides of environment into specs. Must be called before specs are parsed.
+func (s *ProjectActions) ApplyEnvironment(name string) (*Environment, error) {
+	environment, exists := s.Environments[name]
+	if !exists {
+		return nil, errors.Errorf("environment %s not found, configured environments {%s}", name, s.environmentNames())
+	}
+
+	specs := make(NamedActionSpecs)
+	for specName, spec := range s.Specs {
+		specs[specName] = spec
+	}
+	for specName, override := range environment.Specs {
+		spec, exists := specs[specName]
+		if !exists {
+			return nil, errors.Errorf("environment %s overrides action %s which is not configured", name, specName)
+		}
+		merged, err := mergeSpec(spec, override)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("environment %s, action %s", name, specName))
+		}
+		specs[specName] = merged
+	}
+	s.Specs = specs
+
+	return &environment, nil
+}
+
+// ResolveSecrets expands environment variables referenced in secret values. Referencing unset variable is an error,
+// so a missing CI variable doesn't silently clear the secret.
+func (e *Environment) ResolveSecrets(lookup func(string) (string, bool)) (map[string]string, error) {
+	secrets := make(map[string]string)
+	for key, value := range e.Secrets {
+		var missing []string
+		secrets[key] = os.Expand(value, func(variable string) string {
+			resolved, found := lookup(variable)
+			if !found {
+				missing = append(missing, variable)
+			}
+			return resolved
+		})
+		if len(missing) > 0 {
+			return nil, errors.Errorf("secret %s references unset environment variable %s", key, missing[0])
+		}
+	}
+	return secrets, nil
+}
+
+func (s *ProjectActions) environmentNames() string {
+	var names []string
+	for name := range s.Environments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// mergeSpec returns copy of spec with override merged into it, through its json representation.
+func mergeSpec(spec *ActionSpec, override map[string]interface{}) (*ActionSpec, error) {
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal spec")
+	}
+	var base map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	// Keeps big block numbers and values exact
+	decoder.UseNumber()
+	err = decoder.Decode(&base)
+	if err != nil {
+		return nil, errors.Wrap(err, "unmarshal spec")
+	}
+
+	content, err = json.Marshal(merge(base, override))
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal override")
+	}
+	var merged ActionSpec
+	err = json.Unmarshal(content, &merged)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid override")
+	}
+	return &merged, nil
+}
+
+// Keys which can't be set together, overriding one of them removes the other from base.
+var mergeExclusiveKeys = map[string]string{
+	"interval": "cron",
+	"cron":     "interval",
+}
+
+func merge(base interface{}, override interface{}) interface{} {
+	switch overrideValue := override.(type) {
+	case map[string]interface{}:
+		baseMap, ok := base.(map[string]interface{})
+		if !ok {
+			return overrideValue
+		}
+		merged := make(map[string]interface{}, len(baseMap))
+		for key, value := range baseMap {
+			merged[key] = value
+		}
+		for key := range overrideValue {
+			if exclusive, ok := mergeExclusiveKeys[key]; ok {
+				if _, overridden := overrideValue[exclusive]; !overridden {
+					delete(merged, exclusive)
+				}
+			}
+		}
+		for key, value := range overrideValue {
+			merged[key] = merge(baseMap[key], value)
+		}
+		return merged
+	default:
+		return overrideValue
+	}
+}
@@ -0,0 +1,149 @@
+package actions_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+	"gopkg.in/yaml.v3"
+)
+
+func mustReadProjectActions(t *testing.T, filename string) actions.ProjectActions {
+	var project actions.ProjectActions
+	err := yaml.Unmarshal(MustReadTest(filename), &project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return project
+}
+
+func TestApplyEnvironment(t *testing.T) {
+	project := mustReadProjectActions(t, "project_environments")
+	original := project.Specs["watcher"]
+
+	environment, err := project.ApplyEnvironment("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if environment.Project == nil || *environment.Project != "me/proj-staging" {
+		t.Errorf("expected staging project, got %v", environment.Project)
+	}
+
+	for name, spec := range project.Specs {
+		err = spec.Parse()
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		response := spec.TriggerParsed.Validate(actions.ValidatorContext(name))
+		if len(response.Errors) > 0 {
+			t.Fatalf("%s: %v", name, response.Errors)
+		}
+	}
+
+	filters := project.Specs["watcher"].TriggerParsed.Transaction.Filters
+	if len(filters) != 1 {
+		t.Fatalf("expected filters replaced by override, got %d filters", len(filters))
+	}
+	if filters[0].Network.ToRequest()[0] != "5" || filters[0].To.Values[0].String() != "0xa2b6d4c1e9f3a7b5c8d0e2f4a6b8c0d2e4f6a8b0" {
+		t.Errorf("expected filter overridden, got %v", filters[0].ToRequest())
+	}
+	if *project.Specs["reporter"].TriggerParsed.Periodic.Cron != "*/10 * * * *" {
+		t.Errorf("expected cron overridden, got %s", *project.Specs["reporter"].TriggerParsed.Periodic.Cron)
+	}
+	if project.Specs["watcher"].Function != "watcher:run" {
+		t.Errorf("expected function kept, got %s", project.Specs["watcher"].Function)
+	}
+	if original.TriggerParsed != nil {
+		t.Error("expected original spec untouched")
+	}
+}
+
+func TestApplyEnvironmentReplacesLists(t *testing.T) {
+	project := mustReadProjectActions(t, "project_environments")
+	_, err := project.ApplyEnvironment("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spec := project.Specs["indexer"]
+	err = spec.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	networks := spec.TriggerParsed.Block.Network.ToRequest()
+	if len(networks) != 1 || networks[0] != "5" {
+		t.Errorf("expected networks replaced by override, got %v", networks)
+	}
+}
+
+func TestApplyEnvironmentIntervalAndCron(t *testing.T) {
+	project := mustReadProjectActions(t, "project_environments")
+	project.Environments["staging"].Specs["reporter"] = map[string]interface{}{
+		"trigger": map[string]interface{}{"periodic": map[string]interface{}{"interval": "1h"}},
+	}
+	_, err := project.ApplyEnvironment("staging")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, expected := range map[string]actions.PeriodicTrigger{
+		"ticker":   {Cron: stringPtr("0 * * * *")},
+		"reporter": {Interval: stringPtr("1h")},
+	} {
+		spec := project.Specs[name]
+		err = spec.Parse()
+		if err != nil {
+			t.Fatal(err)
+		}
+		periodic := spec.TriggerParsed.Periodic
+		if !reflect.DeepEqual(*periodic, expected) {
+			t.Errorf("%s: expected %+v, got %+v", name, expected, *periodic)
+		}
+		response := spec.TriggerParsed.Validate(actions.ValidatorContext(name))
+		if len(response.Errors) > 0 {
+			t.Errorf("%s: %v", name, response.Errors)
+		}
+	}
+}
+
+func stringPtr(value string) *string {
+	return &value
+}
+
+func TestApplyEnvironmentErrors(t *testing.T) {
+	project := mustReadProjectActions(t, "project_environments")
+	_, err := project.ApplyEnvironment("production")
+	if err == nil || err.Error() != "environment production not found, configured environments {staging}" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	project.Environments["staging"].Specs["unknown"] = map[string]interface{}{"function": "x:y"}
+	_, err = project.ApplyEnvironment("staging")
+	if err == nil {
+		t.Error("expected error for override of unknown action")
+	}
+}
+
+func TestResolveSecrets(t *testing.T) {
+	project := mustReadProjectActions(t, "project_environments")
+	environment := project.Environments["staging"]
+
+	variables := map[string]string{"STAGING_API_KEY": "key", "STAGING_HOST": "staging.example.com"}
+	lookup := func(name string) (string, bool) {
+		value, found := variables[name]
+		return value, found
+	}
+	secrets, err := environment.ResolveSecrets(lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secrets["API_KEY"] != "key" || secrets["API_URL"] != "https://staging.example.com/api" {
+		t.Errorf("unexpected secrets %v", secrets)
+	}
+
+	delete(variables, "STAGING_HOST")
+	_, err = environment.ResolveSecrets(lookup)
+	if err == nil {
+		t.Error("expected error for unset environment variable")
+	}
+}
@@ -0,0 +1,173 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+)
+
+const (
+	FixturesDir      = "fixtures"
+	fixtureExtension = ".json"
+	fixtureTimestamp = "20060102T150405Z"
+)
+
+// Fixture is a recorded execution of deployed action: the event it received and how it ended.
+// Replaying fixture runs the action locally with the same event and compares the outcome.
+type Fixture struct {
+	CallID    string          `json:"callId"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Payload   actions.Payload `json:"payload"`
+	Success   bool            `json:"success"`
+	Error     *FixtureError   `json:"error,omitempty"`
+	Logs      []string        `json:"logs,omitempty"`
+
+	// Path of the file fixture is loaded from or saved to
+	Path string `json:"-"`
+}
+
+type FixtureError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+// FixtureOutcome is how local execution of fixture ended, compared against recorded outcome.
+type FixtureOutcome struct {
+	Success bool
+	Error   *FixtureError
+	Logs    []string
+}
+
+// NewFixture records finished execution. Executions which are still running can't be recorded.
+func NewFixture(call actions.Call) (*Fixture, error) {
+	status := call.Status.Value()
+	if status != actions.CallStatus_SUCCEEDED && status != actions.CallStatus_FAILED {
+		return nil, errors.Errorf("execution %s is not finished, status %s", call.Id, status)
+	}
+
+	fixture := &Fixture{
+		CallID:    call.Id,
+		CreatedAt: time.Time(call.CreatedAt).UTC(),
+		Payload:   call.Payload,
+		Success:   status == actions.CallStatus_SUCCEEDED,
+	}
+	if call.ParsedError != nil {
+		fixture.Error = &FixtureError{Name: call.ParsedError.Name, Message: call.ParsedError.Message}
+	}
+	if call.ParsedLogs != nil {
+		for _, line := range call.ParsedLogs.Lines {
+			fixture.Logs = append(fixture.Logs, line.Message)
+		}
+	}
+	return fixture, nil
+}
+
+func (f *Fixture) FileName() string {
+	return FixtureFileName(f.CreatedAt, f.CallID)
+}
+
+// FixtureFileName is unique per execution and sorts fixtures from oldest to newest.
+func FixtureFileName(createdAt time.Time, callID string) string {
+	return fmt.Sprintf("%s-%s%s", createdAt.UTC().Format(fixtureTimestamp), callID, fixtureExtension)
+}
+
+// Compare returns differences between recorded outcome and outcome of local execution, empty if they match.
+// Logs are compared only if compareLogs is set, as they often contain timestamps or other volatile values.
+func (f *Fixture) Compare(outcome FixtureOutcome, compareLogs bool) (differences []string) {
+	if f.Success != outcome.Success {
+		differences = append(differences, fmt.Sprintf(
+			"recorded execution %s, local execution %s", fixtureStatus(f.Success), fixtureStatus(outcome.Success),
+		))
+	}
+	if f.Error != nil && outcome.Error != nil && *f.Error != *outcome.Error {
+		differences = append(differences, fmt.Sprintf(
+			"recorded error %s: %s, local error %s: %s",
+			f.Error.Name, f.Error.Message, outcome.Error.Name, outcome.Error.Message,
+		))
+	}
+	if !compareLogs {
+		return differences
+	}
+
+	for i := 0; i < len(f.Logs) || i < len(outcome.Logs); i++ {
+		switch {
+		case i >= len(outcome.Logs):
+			differences = append(differences, fmt.Sprintf("log %d: recorded %q, missing locally", i+1, f.Logs[i]))
+		case i >= len(f.Logs):
+			differences = append(differences, fmt.Sprintf("log %d: not recorded, local %q", i+1, outcome.Logs[i]))
+		case f.Logs[i] != outcome.Logs[i]:
+			differences = append(differences, fmt.Sprintf("log %d: recorded %q, local %q", i+1, f.Logs[i], outcome.Logs[i]))
+		}
+	}
+	return differences
+}
+
+func fixtureStatus(success bool) string {
+	if success {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+// SaveFixture writes fixture to dir as indented JSON and sets its path.
+func SaveFixture(dir string, fixture *Fixture) error {
+	err := os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return errors.Wrapf(err, "create directory %s", dir)
+	}
+
+	content, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "marshal fixture")
+	}
+	path := filepath.Join(dir, fixture.FileName())
+	err = os.WriteFile(path, append(content, '\n'), os.FileMode(0644))
+	if err != nil {
+		return errors.Wrapf(err, "write fixture %s", path)
+	}
+	fixture.Path = path
+	return nil
+}
+
+// LoadFixtures reads fixtures from dir, oldest first. Returns no fixtures if dir doesn't exist.
+func LoadFixtures(dir string) ([]*Fixture, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "read directory %s", dir)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), fixtureExtension) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	var fixtures []*Fixture
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "read fixture %s", path)
+		}
+		var fixture Fixture
+		err = json.Unmarshal(content, &fixture)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse fixture %s", path)
+		}
+		fixture.Path = path
+		fixtures = append(fixtures, &fixture)
+	}
+	return fixtures, nil
+}
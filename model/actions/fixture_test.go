@@ -0,0 +1,112 @@
+package actions_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/palantir/pkg/datetime"
+	"github.com/tenderly/tenderly-cli/model/actions"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+)
+
+func newTestCall(status generatedActions.CallStatus_Value) generatedActions.Call {
+	return generatedActions.Call{
+		Id:        "call-1",
+		CreatedAt: datetime.DateTime(time.Date(2024, 5, 15, 12, 30, 0, 0, time.UTC)),
+		Status:    generatedActions.New_CallStatus(status),
+		Payload: generatedActions.NewPayloadFromBlock(generatedActions.BlockPayload{
+			Network:     "1",
+			BlockNumber: 100,
+			BlockHash:   "0x01",
+		}),
+		ParsedError: &generatedActions.CallError{Name: "Error", Message: "boom"},
+		ParsedLogs: &generatedActions.CallLog{Lines: []generatedActions.CallLogLine{
+			{Severity: "INFO", Message: "block 100"},
+		}},
+	}
+}
+
+func TestFixtureSaveAndLoad(t *testing.T) {
+	fixture, err := actions.NewFixture(newTestCall(generatedActions.CallStatus_FAILED))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	err = actions.SaveFixture(dir, fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fixture.FileName() != "20240515T123000Z-call-1.json" {
+		t.Errorf("unexpected file name %s", fixture.FileName())
+	}
+
+	fixtures, err := actions.LoadFixtures(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) != 1 {
+		t.Fatalf("expected 1 fixture, got %d", len(fixtures))
+	}
+	loaded := fixtures[0]
+	if loaded.Path != fixture.Path || loaded.Success || loaded.Error.Message != "boom" || loaded.Logs[0] != "block 100" {
+		t.Errorf("unexpected loaded fixture %+v", loaded)
+	}
+	expected, _ := json.Marshal(fixture.Payload)
+	actual, _ := json.Marshal(loaded.Payload)
+	if string(expected) != string(actual) {
+		t.Errorf("expected payload %s, got %s", expected, actual)
+	}
+}
+
+func TestFixtureNotFinished(t *testing.T) {
+	_, err := actions.NewFixture(newTestCall(generatedActions.CallStatus_SUBMITTED))
+	if err == nil {
+		t.Error("expected error for execution which is not finished")
+	}
+}
+
+func TestLoadFixturesMissingDir(t *testing.T) {
+	fixtures, err := actions.LoadFixtures(t.TempDir() + "/missing")
+	if err != nil || len(fixtures) != 0 {
+		t.Errorf("expected no fixtures, got %v %v", fixtures, err)
+	}
+}
+
+func TestFixtureCompare(t *testing.T) {
+	fixture, err := actions.NewFixture(newTestCall(generatedActions.CallStatus_FAILED))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	same := actions.FixtureOutcome{
+		Error: &actions.FixtureError{Name: "Error", Message: "boom"},
+		Logs:  []string{"block 100"},
+	}
+	if differences := fixture.Compare(same, true); len(differences) != 0 {
+		t.Errorf("expected no differences, got %v", differences)
+	}
+
+	different := actions.FixtureOutcome{
+		Success: true,
+		Logs:    []string{"block 101", "done"},
+	}
+	if differences := fixture.Compare(different, false); len(differences) != 1 {
+		t.Errorf("expected only status difference without logs, got %v", differences)
+	}
+	differences := fixture.Compare(different, true)
+	expected := []string{
+		"recorded execution failed, local execution succeeded",
+		`log 1: recorded "block 100", local "block 101"`,
+		`log 2: not recorded, local "done"`,
+	}
+	if len(differences) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, differences)
+	}
+	for i := range expected {
+		if differences[i] != expected[i] {
+			t.Errorf("expected %q, got %q", expected[i], differences[i])
+		}
+	}
+}
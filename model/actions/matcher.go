@@ -0,0 +1,777 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+)
+
+// MatchOutcome is the result of evaluating a filter offline. Some filters can't be decided from the transaction
+// payload alone (e.g. they need state diff or ABI which isn't loaded), those are reported as unknown instead of guessing.
+type MatchOutcome string
+
+const (
+	MatchMatched  MatchOutcome = "matched"
+	MatchRejected MatchOutcome = "rejected"
+	MatchUnknown  MatchOutcome = "unknown"
+)
+
+// RecordedTransaction is transaction payload with execution status, which is not part of the payload but is needed
+// for status filter.
+type RecordedTransaction struct {
+	Payload actions.TransactionPayload
+	// Nil if status is not recorded
+	Success *bool
+}
+
+// ParseRecordedTransaction accepts either bare transaction payload or payload wrapped in event union
+// ({"type": "transaction", "transaction": {...}}). Optional "status" can be boolean, 0 / 1, "0x0" / "0x1" or
+// "success" / "fail".
+func ParseRecordedTransaction(content []byte) (*RecordedTransaction, error) {
+	var wrapper struct {
+		Type        string          `json:"type"`
+		Transaction json.RawMessage `json:"transaction"`
+		Status      json.RawMessage `json:"status"`
+	}
+	err := json.Unmarshal(content, &wrapper)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse transaction")
+	}
+
+	txContent := content
+	if wrapper.Type != "" {
+		if !strings.EqualFold(wrapper.Type, string(TransactionType)) {
+			return nil, errors.Errorf("expected transaction payload, got %s", wrapper.Type)
+		}
+		if wrapper.Transaction == nil {
+			return nil, errors.New("missing transaction field")
+		}
+		txContent = wrapper.Transaction
+	}
+
+	var tx RecordedTransaction
+	err = json.Unmarshal(txContent, &tx.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse transaction payload")
+	}
+
+	status := wrapper.Status
+	if wrapper.Type != "" {
+		var inner struct {
+			Status json.RawMessage `json:"status"`
+		}
+		if err := json.Unmarshal(txContent, &inner); err == nil && inner.Status != nil {
+			status = inner.Status
+		}
+	}
+	if status != nil {
+		tx.Success, err = parseRecordedStatus(status)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &tx, nil
+}
+
+func parseRecordedStatus(raw json.RawMessage) (*bool, error) {
+	var value interface{}
+	err := json.Unmarshal(raw, &value)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse transaction status")
+	}
+
+	var success bool
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		success = v
+	case float64:
+		success = v == 1
+	case string:
+		switch strings.ToLower(v) {
+		case "1", "0x1", "true", "success":
+			success = true
+		case "0", "0x0", "false", "fail", "failed":
+			success = false
+		default:
+			return nil, errors.Errorf("unrecognized transaction status %s", v)
+		}
+	default:
+		return nil, errors.Errorf("unrecognized transaction status %v", v)
+	}
+	return &success, nil
+}
+
+// SubFilterResult is outcome of a single field in transaction filter, e.g. network or function.
+type SubFilterResult struct {
+	Field   string       `json:"field"`
+	Outcome MatchOutcome `json:"outcome"`
+	Reason  string       `json:"reason"`
+}
+
+type FilterMatchResult struct {
+	Index      int               `json:"index"`
+	Outcome    MatchOutcome      `json:"outcome"`
+	SubFilters []SubFilterResult `json:"subFilters"`
+}
+
+// Rejected returns sub filters that rejected the transaction.
+func (f *FilterMatchResult) Rejected() (response []SubFilterResult) {
+	for _, sub := range f.SubFilters {
+		if sub.Outcome == MatchRejected {
+			response = append(response, sub)
+		}
+	}
+	return response
+}
+
+type TriggerMatchResult struct {
+	Outcome MatchOutcome        `json:"outcome"`
+	Filters []FilterMatchResult `json:"filters"`
+}
+
+// Match evaluates trigger filters against recorded transaction. Filters are alternatives, so the trigger matches if
+// any filter matches. Within a filter all fields must match, and within a field any of the values must match.
+// Function and event names are resolved and parameters decoded with ABIs of contracts found in abis.
+// Trigger must be validated before calling this, since validation normalizes values and propagates contract.
+func (t *TransactionTrigger) Match(tx *RecordedTransaction, abis ContractABIs) TriggerMatchResult {
+	result := TriggerMatchResult{Outcome: MatchRejected}
+	for i := range t.Filters {
+		filterResult := t.Filters[i].Match(tx, abis)
+		filterResult.Index = i
+		result.Filters = append(result.Filters, filterResult)
+
+		if filterResult.Outcome == MatchMatched {
+			result.Outcome = MatchMatched
+		} else if filterResult.Outcome == MatchUnknown && result.Outcome == MatchRejected {
+			result.Outcome = MatchUnknown
+		}
+	}
+	return result
+}
+
+func (t *TransactionFilter) Match(tx *RecordedTransaction, abis ContractABIs) FilterMatchResult {
+	var subFilters []SubFilterResult
+	add := func(field string, outcome MatchOutcome, reason string) {
+		subFilters = append(subFilters, SubFilterResult{Field: field, Outcome: outcome, Reason: reason})
+	}
+
+	payload := tx.Payload
+	if t.Network != nil {
+		outcome, reason := matchNetwork(t.Network, payload.Network)
+		add("network", outcome, reason)
+	}
+	if t.Status != nil {
+		outcome, reason := matchStatus(t.Status, tx.Success)
+		add("status", outcome, reason)
+	}
+	if t.From != nil {
+		outcome, reason := matchAddress(t.From, &payload.From)
+		add("from", outcome, reason)
+	}
+	if t.To != nil {
+		outcome, reason := matchAddress(t.To, payload.To)
+		add("to", outcome, reason)
+	}
+	if t.Value != nil {
+		outcome, reason := matchInt(t.Value, parseBigInt(payload.Value))
+		add("value", outcome, reason)
+	}
+	if t.GasLimit != nil {
+		outcome, reason := matchInt(t.GasLimit, parseBigInt(payload.Gas))
+		add("gasLimit", outcome, reason)
+	}
+	if t.GasUsed != nil {
+		outcome, reason := matchInt(t.GasUsed, parseBigInt(payload.GasUsed))
+		add("gasUsed", outcome, reason)
+	}
+	if t.Fee != nil {
+		var fee *big.Int
+		gasUsed, gasPrice := parseBigInt(payload.GasUsed), parseBigInt(payload.GasPrice)
+		if gasUsed != nil && gasPrice != nil {
+			fee = new(big.Int).Mul(gasUsed, gasPrice)
+		}
+		outcome, reason := matchInt(t.Fee, fee)
+		add("fee", outcome, reason)
+	}
+	if t.Function != nil {
+		outcome, reason := matchAny(len(t.Function.Values), func(i int) (MatchOutcome, string) {
+			return t.Function.Values[i].match(&payload, abis)
+		})
+		add("function", outcome, reason)
+	}
+	if t.EventEmitted != nil {
+		outcome, reason := matchAny(len(t.EventEmitted.Values), func(i int) (MatchOutcome, string) {
+			return t.EventEmitted.Values[i].match(&payload, abis)
+		})
+		add("eventEmitted", outcome, reason)
+	}
+	if t.LogEmitted != nil {
+		outcome, reason := matchAny(len(t.LogEmitted.Values), func(i int) (MatchOutcome, string) {
+			return t.LogEmitted.Values[i].match(&payload)
+		})
+		add("logEmitted", outcome, reason)
+	}
+	if t.EthBalance != nil {
+		add("ethBalance", MatchUnknown, "balance changes are not part of the transaction payload")
+	}
+	if t.StateChanged != nil {
+		add("stateChanged", MatchUnknown, "state changes are not part of the transaction payload")
+	}
+
+	outcome := MatchMatched
+	for _, sub := range subFilters {
+		if sub.Outcome == MatchRejected {
+			outcome = MatchRejected
+			break
+		}
+		if sub.Outcome == MatchUnknown {
+			outcome = MatchUnknown
+		}
+	}
+
+	return FilterMatchResult{
+		Outcome:    outcome,
+		SubFilters: subFilters,
+	}
+}
+
+func (f *FunctionValue) match(tx *actions.TransactionPayload, abis ContractABIs) (MatchOutcome, string) {
+	var reasons []string
+	outcome := MatchMatched
+
+	var contractABI *abi.ABI
+	if f.Contract != nil {
+		contractOutcome, reason := f.Contract.match(tx.To)
+		outcome = combineAll(outcome, contractOutcome)
+		reasons = append(reasons, reason)
+		contractABI = abis.Find(f.Contract.Address.String(), []string{tx.Network})
+	}
+
+	// Function called by transaction input, if it is found in ABI
+	var method *abi.Method
+	switch {
+	case f.Signature != nil:
+		if tx.Input == nil {
+			outcome = combineAll(outcome, MatchUnknown)
+			reasons = append(reasons, "transaction input is not recorded")
+		} else if !strings.HasPrefix(strings.ToLower(*tx.Input), strings.ToLower(f.Signature.Value)) {
+			outcome = combineAll(outcome, MatchRejected)
+			reasons = append(reasons, fmt.Sprintf("input %s doesn't start with signature %s", shortHex(*tx.Input), f.Signature.Value))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("input starts with signature %s", f.Signature.Value))
+			method = findMethod(contractABI, *tx.Input, "")
+		}
+	case f.Name != nil && contractABI == nil:
+		outcome = combineAll(outcome, MatchUnknown)
+		reasons = append(reasons, fmt.Sprintf("function name %s can't be resolved without ABI, use signature", *f.Name))
+	case f.Name != nil && tx.Input == nil:
+		outcome = combineAll(outcome, MatchUnknown)
+		reasons = append(reasons, "transaction input is not recorded")
+	case f.Name != nil:
+		method = findMethod(contractABI, *tx.Input, *f.Name)
+		if method == nil {
+			outcome = combineAll(outcome, MatchRejected)
+			reasons = append(reasons, fmt.Sprintf("input %s doesn't call function %s", shortHex(*tx.Input), *f.Name))
+		} else {
+			reasons = append(reasons, fmt.Sprintf("input calls function %s", method.Sig))
+		}
+	}
+
+	if len(f.Parameters) > 0 && outcome != MatchRejected {
+		var values map[string]interface{}
+		err := errors.New("parameters can't be decoded without ABI")
+		if method != nil {
+			values, err = decodeMethodInput(method, *tx.Input)
+		}
+		if err != nil {
+			outcome = combineAll(outcome, MatchUnknown)
+			reasons = append(reasons, err.Error())
+		} else {
+			parametersOutcome, reason := matchParameters(f.Parameters, values)
+			outcome = combineAll(outcome, parametersOutcome)
+			reasons = append(reasons, reason)
+		}
+	}
+
+	return negate(outcome, f.Not), joinReasons(reasons, f.Not)
+}
+
+func (r *EventEmittedValue) match(tx *actions.TransactionPayload, abis ContractABIs) (MatchOutcome, string) {
+	var contractABI *abi.ABI
+	if r.Contract != nil {
+		contractABI = abis.Find(r.Contract.Address.String(), []string{tx.Network})
+	}
+
+	// Events by topic, nil for topics of events which are not found in ABI
+	events := make(map[string]*abi.Event)
+	var description string
+	if r.Id != nil {
+		description = fmt.Sprintf("topic %s", *r.Id)
+		events[strings.ToLower(*r.Id)] = nil
+		if contractABI != nil {
+			for _, event := range contractABI.Events {
+				if strings.ToLower(event.ID.Hex()) == strings.ToLower(*r.Id) {
+					event := event
+					events[strings.ToLower(*r.Id)] = &event
+				}
+			}
+		}
+	} else {
+		name := ""
+		if r.Name != nil {
+			name = *r.Name
+		}
+		if contractABI == nil {
+			return MatchUnknown, fmt.Sprintf("event name %s can't be resolved without ABI, use id", name)
+		}
+		description = fmt.Sprintf("event %s", name)
+		for _, event := range contractABI.Events {
+			if event.RawName == name {
+				event := event
+				events[strings.ToLower(event.ID.Hex())] = &event
+			}
+		}
+	}
+
+	outcome := MatchRejected
+	reason := fmt.Sprintf("no log with %s", description)
+	for _, log := range tx.Logs {
+		if len(log.Topics) == 0 {
+			continue
+		}
+		event, ok := events[strings.ToLower(log.Topics[0])]
+		if !ok {
+			continue
+		}
+		if r.Contract != nil && !strings.EqualFold(log.Address, r.Contract.Address.String()) {
+			if outcome == MatchRejected {
+				reason = fmt.Sprintf("log with %s emitted by %s, not %s", description, log.Address, r.Contract.Address.String())
+			}
+			continue
+		}
+
+		logOutcome := MatchMatched
+		logReason := fmt.Sprintf("log with %s emitted by %s", description, log.Address)
+		if len(r.Parameters) > 0 {
+			var values map[string]interface{}
+			err := errors.New("parameters can't be decoded without ABI")
+			if event != nil {
+				values, err = decodeEventLog(event, log)
+			}
+			if err != nil {
+				logOutcome = MatchUnknown
+				logReason += ", " + err.Error()
+			} else {
+				var parametersReason string
+				logOutcome, parametersReason = matchParameters(r.Parameters, values)
+				logReason += ", " + parametersReason
+			}
+		}
+
+		if logOutcome == MatchMatched {
+			outcome, reason = MatchMatched, logReason
+			break
+		}
+		// Reason of the first unknown log is kept, otherwise of the last rejected one
+		if outcome == MatchRejected {
+			outcome, reason = logOutcome, logReason
+		}
+	}
+
+	return negate(outcome, r.Not), joinReasons([]string{reason}, r.Not)
+}
+
+// findMethod returns method of contractABI called by input. If name is set, only methods with that name
+// are considered. Returns nil if contractABI is nil or no method matches.
+func findMethod(contractABI *abi.ABI, input string, name string) *abi.Method {
+	if contractABI == nil {
+		return nil
+	}
+	for _, method := range contractABI.Methods {
+		if name != "" && method.RawName != name {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(input), hexutil.Encode(method.ID)) {
+			method := method
+			return &method
+		}
+	}
+	return nil
+}
+
+func decodeMethodInput(method *abi.Method, input string) (map[string]interface{}, error) {
+	data, err := hexutil.Decode(input)
+	if err != nil || len(data) < 4 {
+		return nil, errors.Errorf("input %s can't be decoded", shortHex(input))
+	}
+	values := make(map[string]interface{})
+	err = method.Inputs.UnpackIntoMap(values, data[4:])
+	if err != nil {
+		return nil, errors.Errorf("input of %s can't be decoded: %s", method.Sig, err)
+	}
+	return values, nil
+}
+
+// decodeEventLog decodes inputs of event from log. Indexed inputs of reference types are topic hashes.
+func decodeEventLog(event *abi.Event, log actions.TransactionLog) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	data, err := hexutil.Decode(log.Data)
+	if err != nil && log.Data != "" && log.Data != "0x" {
+		return nil, errors.Errorf("log data of %s can't be decoded", event.Sig)
+	}
+	err = event.Inputs.NonIndexed().UnpackIntoMap(values, data)
+	if err != nil {
+		return nil, errors.Errorf("log data of %s can't be decoded: %s", event.Sig, err)
+	}
+
+	var indexed abi.Arguments
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	topics := make([]common.Hash, 0, len(log.Topics))
+	for _, topic := range log.Topics[1:] {
+		topics = append(topics, common.HexToHash(topic))
+	}
+	err = abi.ParseTopicsIntoMap(values, indexed, topics)
+	if err != nil {
+		return nil, errors.Errorf("log topics of %s can't be decoded: %s", event.Sig, err)
+	}
+	return values, nil
+}
+
+// matchParameters evaluates parameter conditions against decoded values, all of them must match.
+func matchParameters(parameters []ParameterCondValue, values map[string]interface{}) (MatchOutcome, string) {
+	outcome := MatchMatched
+	var reasons []string
+	for _, p := range parameters {
+		value, ok := values[p.Name]
+		if !ok {
+			outcome = combineAll(outcome, MatchRejected)
+			reasons = append(reasons, fmt.Sprintf("parameter %s not found", p.Name))
+			continue
+		}
+		if p.Int != nil {
+			parameterOutcome, reason := matchInt(&IntField{Values: []IntValue{*p.Int}}, abiBigInt(value))
+			outcome = combineAll(outcome, parameterOutcome)
+			reasons = append(reasons, fmt.Sprintf("parameter %s %s", p.Name, reason))
+		}
+		if p.String != nil && p.String.Exact != nil {
+			parameterOutcome, reason := p.String.match(abiString(value))
+			outcome = combineAll(outcome, parameterOutcome)
+			reasons = append(reasons, fmt.Sprintf("parameter %s %s", p.Name, reason))
+		}
+	}
+	return outcome, strings.Join(reasons, ", ")
+}
+
+// match compares value exactly, except hex values like addresses which are compared ignoring case.
+func (v *StrValue) match(value string) (MatchOutcome, string) {
+	equal := value == *v.Exact || (strings.HasPrefix(value, "0x") && strings.EqualFold(value, *v.Exact))
+	outcome := MatchRejected
+	if equal {
+		outcome = MatchMatched
+	}
+	if v.Not {
+		return negate(outcome, true), fmt.Sprintf("%s, expected not %s", value, *v.Exact)
+	}
+	return outcome, fmt.Sprintf("%s, expected %s", value, *v.Exact)
+}
+
+// abiBigInt converts decoded integer of any size to big.Int, returns nil for other values.
+func abiBigInt(value interface{}) *big.Int {
+	if v, ok := value.(*big.Int); ok {
+		return v
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return big.NewInt(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return new(big.Int).SetUint64(rv.Uint())
+	}
+	return nil
+}
+
+// abiString formats decoded value as it is written in string condition: addresses, hashes and bytes as hex.
+func abiString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case common.Address:
+		return strings.ToLower(v.Hex())
+	case common.Hash:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		data := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(data), rv)
+		return hexutil.Encode(data)
+	}
+	return fmt.Sprint(value)
+}
+
+func (l *LogEmittedValue) match(tx *actions.TransactionPayload) (MatchOutcome, string) {
+	outcome := MatchRejected
+	reason := fmt.Sprintf("no log with topics %s", hex64List(l.StartsWith))
+	for _, log := range tx.Logs {
+		if l.Contract != nil && !strings.EqualFold(log.Address, l.Contract.Address.String()) {
+			continue
+		}
+		if l.matchTopics(log.Topics) {
+			outcome = MatchMatched
+			reason = fmt.Sprintf("log with topics %s emitted by %s", hex64List(l.StartsWith), log.Address)
+			break
+		}
+	}
+
+	return negate(outcome, l.Not), joinReasons([]string{reason}, l.Not)
+}
+
+func (l *LogEmittedValue) matchTopics(topics []string) bool {
+	if l.MatchAny {
+		for _, expected := range l.StartsWith {
+			for _, topic := range topics {
+				if strings.EqualFold(topic, expected.Value) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if len(topics) < len(l.StartsWith) {
+		return false
+	}
+	for i, expected := range l.StartsWith {
+		if !strings.EqualFold(topics[i], expected.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *ContractValue) match(to *string) (MatchOutcome, string) {
+	address := c.Address.String()
+	if c.Invocation != nil && *c.Invocation == InvocationInternal {
+		return MatchUnknown, fmt.Sprintf("internal calls to %s need transaction trace", address)
+	}
+	if to == nil {
+		return MatchRejected, "transaction creates contract"
+	}
+	if strings.EqualFold(*to, address) {
+		return MatchMatched, fmt.Sprintf("called %s", address)
+	}
+	if c.Invocation == nil || *c.Invocation == InvocationAny {
+		return MatchUnknown, fmt.Sprintf("called %s, internal calls to %s need transaction trace", *to, address)
+	}
+	return MatchRejected, fmt.Sprintf("called %s, not %s", *to, address)
+}
+
+func matchNetwork(field *NetworkField, network string) (MatchOutcome, string) {
+	for _, value := range field.Value.Values {
+		if value == network {
+			return MatchMatched, fmt.Sprintf("network %s", network)
+		}
+	}
+	return MatchRejected, fmt.Sprintf("network %s not in %v", network, field.Value.Values)
+}
+
+func matchStatus(field *StatusField, success *bool) (MatchOutcome, string) {
+	if success == nil {
+		return MatchUnknown, "transaction status is not recorded"
+	}
+
+	status := strings.ToLower(string(actions.Status_FAIL))
+	if *success {
+		status = strings.ToLower(string(actions.Status_SUCCESS))
+	}
+	for _, value := range field.Value.Values {
+		if value == status {
+			return MatchMatched, fmt.Sprintf("status %s", status)
+		}
+	}
+	return MatchRejected, fmt.Sprintf("status %s not in %v", status, field.Value.Values)
+}
+
+func matchAddress(field *AddressField, address *string) (MatchOutcome, string) {
+	if address == nil {
+		return MatchRejected, "address is empty"
+	}
+	for _, value := range field.Values {
+		if strings.EqualFold(value.String(), *address) {
+			return MatchMatched, fmt.Sprintf("address %s", *address)
+		}
+	}
+	return MatchRejected, fmt.Sprintf("address %s not in %v", *address, addressList(field.Values))
+}
+
+func matchInt(field *IntField, value *big.Int) (MatchOutcome, string) {
+	if value == nil {
+		return MatchUnknown, "value is not recorded"
+	}
+	for _, cmp := range field.Values {
+		if cmp.match(value) {
+			return MatchMatched, fmt.Sprintf("%s satisfies %s", value, cmp.String())
+		}
+	}
+
+	conditions := make([]string, 0, len(field.Values))
+	for _, cmp := range field.Values {
+		conditions = append(conditions, cmp.String())
+	}
+	return MatchRejected, fmt.Sprintf("%s doesn't satisfy any of [%s]", value, strings.Join(conditions, ", "))
+}
+
+func (i *IntValue) match(value *big.Int) bool {
+	ok := true
+	if i.GTE != nil {
+		ok = ok && value.Cmp(big.NewInt(int64(*i.GTE))) >= 0
+	}
+	if i.LTE != nil {
+		ok = ok && value.Cmp(big.NewInt(int64(*i.LTE))) <= 0
+	}
+	if i.EQ != nil {
+		ok = ok && value.Cmp(big.NewInt(int64(*i.EQ))) == 0
+	}
+	if i.GT != nil {
+		ok = ok && value.Cmp(big.NewInt(int64(*i.GT))) > 0
+	}
+	if i.LT != nil {
+		ok = ok && value.Cmp(big.NewInt(int64(*i.LT))) < 0
+	}
+	if i.Not {
+		return !ok
+	}
+	return ok
+}
+
+func (i IntValue) String() string {
+	var conditions []string
+	appendCond := func(op string, value *int) {
+		if value != nil {
+			conditions = append(conditions, fmt.Sprintf("%s %d", op, *value))
+		}
+	}
+	appendCond("gte", i.GTE)
+	appendCond("lte", i.LTE)
+	appendCond("eq", i.EQ)
+	appendCond("gt", i.GT)
+	appendCond("lt", i.LT)
+
+	str := strings.Join(conditions, " and ")
+	if i.Not {
+		return fmt.Sprintf("not (%s)", str)
+	}
+	return str
+}
+
+// matchAny combines field values, any of which is enough for the field to match.
+func matchAny(count int, match func(i int) (MatchOutcome, string)) (MatchOutcome, string) {
+	outcome := MatchRejected
+	var reasons []string
+	for i := 0; i < count; i++ {
+		valueOutcome, reason := match(i)
+		if valueOutcome == MatchMatched {
+			return MatchMatched, reason
+		}
+		if valueOutcome == MatchUnknown {
+			outcome = MatchUnknown
+		}
+		if count > 1 {
+			reason = fmt.Sprintf("[%d] %s", i, reason)
+		}
+		reasons = append(reasons, reason)
+	}
+	return outcome, strings.Join(reasons, "; ")
+}
+
+// combineAll combines conditions which all must match.
+func combineAll(current MatchOutcome, next MatchOutcome) MatchOutcome {
+	if current == MatchRejected || next == MatchRejected {
+		return MatchRejected
+	}
+	if current == MatchUnknown || next == MatchUnknown {
+		return MatchUnknown
+	}
+	return MatchMatched
+}
+
+func negate(outcome MatchOutcome, not bool) MatchOutcome {
+	if !not {
+		return outcome
+	}
+	switch outcome {
+	case MatchMatched:
+		return MatchRejected
+	case MatchRejected:
+		return MatchMatched
+	}
+	return outcome
+}
+
+func joinReasons(reasons []string, not bool) string {
+	reason := strings.Join(reasons, ", ")
+	if not {
+		return fmt.Sprintf("not: %s", reason)
+	}
+	return reason
+}
+
+func parseBigInt(value *string) *big.Int {
+	if value == nil || *value == "" {
+		return nil
+	}
+	str := strings.ToLower(*value)
+	base := 10
+	if strings.HasPrefix(str, "0x") {
+		str = strings.TrimPrefix(str, "0x")
+		base = 16
+	}
+	ret, ok := new(big.Int).SetString(str, base)
+	if !ok {
+		return nil
+	}
+	return ret
+}
+
+func shortHex(value string) string {
+	if len(value) > 10 {
+		return value[:10] + "..."
+	}
+	return value
+}
+
+func hex64List(values []Hex64) []string {
+	ret := make([]string, 0, len(values))
+	for _, value := range values {
+		ret = append(ret, value.Value)
+	}
+	return ret
+}
+
+func addressList(values []AddressValue) []string {
+	ret := make([]string, 0, len(values))
+	for _, value := range values {
+		ret = append(ret, value.String())
+	}
+	return ret
+}
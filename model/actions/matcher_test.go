@@ -0,0 +1,211 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+const matchTransferTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+func mustParseRecordedTransaction(t *testing.T, content string) *actions.RecordedTransaction {
+	tx, err := actions.ParseRecordedTransaction([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func findSubFilter(t *testing.T, result actions.FilterMatchResult, field string) actions.SubFilterResult {
+	for _, sub := range result.SubFilters {
+		if sub.Field == field {
+			return sub
+		}
+	}
+	t.Fatalf("sub filter %s not found", field)
+	return actions.SubFilterResult{}
+}
+
+func TestMatchTransaction(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_transaction_match")
+	tx := mustParseRecordedTransaction(t, `{
+		"type": "transaction",
+		"transaction": {
+			"network": "1",
+			"hash": "0x01",
+			"from": "0xf63c48626f874bf5604d3ba9f4a85d5ce58f8019",
+			"to": "0x13253C152f4D724D15D7B064DE106A739551dA5F",
+			"input": "0x1d6d560f0000000000000000000000000000000000000000000000000000000000000001",
+			"value": "0x3e8",
+			"status": "0x1",
+			"logs": [{
+				"address": "0x13253c152f4d724d15d7b064de106a739551da5f",
+				"topics": ["`+matchTransferTopic+`"],
+				"data": "0x"
+			}]
+		}
+	}`)
+
+	result := trigger.Transaction.Match(tx, nil)
+	if result.Outcome != actions.MatchMatched {
+		t.Fatalf("expected trigger to match, got %s", result.Outcome)
+	}
+	if result.Filters[0].Outcome != actions.MatchMatched {
+		t.Errorf("expected first filter to match, got %s", result.Filters[0].Outcome)
+	}
+	if sub := findSubFilter(t, result.Filters[1], "network"); sub.Outcome != actions.MatchRejected {
+		t.Errorf("expected network of second filter to reject, got %s", sub.Outcome)
+	}
+}
+
+func TestMatchTransactionWrongSignature(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_transaction_match")
+	tx := mustParseRecordedTransaction(t, `{
+		"network": "1",
+		"hash": "0x01",
+		"from": "0x0000000000000000000000000000000000000001",
+		"to": "0x13253c152f4d724d15d7b064de106a739551da5f",
+		"input": "0xa9059cbb",
+		"value": "1000",
+		"status": true,
+		"logs": [{
+			"address": "0x13253c152f4d724d15d7b064de106a739551da5f",
+			"topics": ["`+matchTransferTopic+`"],
+			"data": "0x"
+		}]
+	}`)
+
+	result := trigger.Transaction.Match(tx, nil)
+	if result.Outcome != actions.MatchRejected {
+		t.Fatalf("expected trigger to reject, got %s", result.Outcome)
+	}
+	rejected := result.Filters[0].Rejected()
+	if len(rejected) != 1 || rejected[0].Field != "function" {
+		t.Fatalf("expected only function to reject, got %v", rejected)
+	}
+}
+
+func TestMatchTransactionLogTopics(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_transaction_match")
+	tx := mustParseRecordedTransaction(t, `{
+		"network": "5",
+		"hash": "0x01",
+		"from": "0xF63C48626f874bf5604D3Ba9f4A85d5cE58f8019",
+		"logs": [{
+			"address": "0x0000000000000000000000000000000000000002",
+			"topics": [
+				"`+matchTransferTopic+`",
+				"0x000000000000000000000000f63c48626f874bf5604d3ba9f4a85d5ce58f8019",
+				"0x0000000000000000000000000000000000000000000000000000000000000003"
+			],
+			"data": "0x"
+		}]
+	}`)
+
+	result := trigger.Transaction.Match(tx, nil)
+	if result.Filters[1].Outcome != actions.MatchMatched {
+		t.Fatalf("expected second filter to match, got %v", result.Filters[1])
+	}
+
+	tx.Payload.Logs[0].Topics = tx.Payload.Logs[0].Topics[:1]
+	result = trigger.Transaction.Match(tx, nil)
+	if sub := findSubFilter(t, result.Filters[1], "logEmitted"); sub.Outcome != actions.MatchRejected {
+		t.Errorf("expected logEmitted to reject when topic is missing, got %s", sub.Outcome)
+	}
+}
+
+func TestMatchTransactionUnknown(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_function_name")
+	tx := mustParseRecordedTransaction(t, `{
+		"network": "1",
+		"hash": "0x01",
+		"from": "0x0000000000000000000000000000000000000001",
+		"to": "0x13253c152f4d724d15d7b064de106a739551da5f"
+	}`)
+
+	result := trigger.Transaction.Match(tx, nil)
+	if result.Outcome != actions.MatchUnknown {
+		t.Fatalf("expected function name to be unknown without ABI, got %s", result.Outcome)
+	}
+}
+
+func TestMatchTransactionNot(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_transaction_not")
+	tx := mustParseRecordedTransaction(t, `{
+		"network": "1",
+		"hash": "0x01",
+		"from": "0xf63c48626f874bf5604d3ba9f4a85d5ce58f8019",
+		"value": "100",
+		"logs": []
+	}`)
+
+	result := trigger.Transaction.Match(tx, nil)
+	if sub := findSubFilter(t, result.Filters[0], "value"); sub.Outcome != actions.MatchRejected {
+		t.Errorf("expected negated value to reject, got %s", sub.Outcome)
+	}
+	if sub := findSubFilter(t, result.Filters[0], "logEmitted"); sub.Outcome != actions.MatchMatched {
+		t.Errorf("expected negated logEmitted to match when no logs, got %s", sub.Outcome)
+	}
+}
+
+func matchABITransaction(amount string) string {
+	return `{
+		"network": "1",
+		"hash": "0x01",
+		"from": "0x0000000000000000000000000000000000000001",
+		"to": "0x13253c152f4d724d15d7b064de106a739551da5f",
+		"input": "0xa9059cbb000000000000000000000000f63c48626f874bf5604d3ba9f4a85d5ce58f8019` + amount + `",
+		"logs": [{
+			"address": "0x13253c152f4d724d15d7b064de106a739551da5f",
+			"topics": [
+				"` + matchTransferTopic + `",
+				"0x0000000000000000000000000000000000000000000000000000000000000001",
+				"0x000000000000000000000000f63c48626f874bf5604d3ba9f4a85d5ce58f8019"
+			],
+			"data": "0x` + amount + `"
+		}]
+	}`
+}
+
+func TestMatchTransactionABI(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_transaction_match_abi")
+	abis := mustERC20ABIs(t, "1")
+
+	// 1000
+	tx := mustParseRecordedTransaction(t, matchABITransaction("00000000000000000000000000000000000000000000000000000000000003e8"))
+	result := trigger.Transaction.Match(tx, abis)
+	if result.Outcome != actions.MatchMatched {
+		t.Fatalf("expected trigger to match with abi, got %s: %v", result.Outcome, result.Filters[0].SubFilters)
+	}
+
+	// 100
+	tx = mustParseRecordedTransaction(t, matchABITransaction("0000000000000000000000000000000000000000000000000000000000000064"))
+	result = trigger.Transaction.Match(tx, abis)
+	if sub := findSubFilter(t, result.Filters[0], "function"); sub.Outcome != actions.MatchRejected {
+		t.Errorf("expected function amount to reject, got %s: %s", sub.Outcome, sub.Reason)
+	}
+	if sub := findSubFilter(t, result.Filters[0], "eventEmitted"); sub.Outcome != actions.MatchRejected {
+		t.Errorf("expected event value to reject, got %s: %s", sub.Outcome, sub.Reason)
+	}
+
+	result = trigger.Transaction.Match(tx, nil)
+	if result.Outcome != actions.MatchUnknown {
+		t.Errorf("expected trigger to be unknown without abi, got %s", result.Outcome)
+	}
+}
+
+func TestMatchTransactionABIOtherFunction(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_function_name")
+	tx := mustParseRecordedTransaction(t, matchABITransaction("00000000000000000000000000000000000000000000000000000000000003e8"))
+
+	abis := make(actions.ContractABIs)
+	err := abis.Add("1", "0x13253c152f4D724D15D7B064DE106A739551dA5F",
+		[]byte(`[{"type":"function","name":"myFunction","inputs":[],"outputs":[]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := trigger.Transaction.Match(tx, abis)
+	if result.Outcome != actions.MatchRejected {
+		t.Errorf("expected call of other function to reject, got %s", result.Outcome)
+	}
+}
@@ -0,0 +1,163 @@
+package actions
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+)
+
+const (
+	zeroAddress = "0x0000000000000000000000000000000000000000"
+	zeroHash    = "0x0000000000000000000000000000000000000000000000000000000000000000"
+
+	defaultPayloadNetwork = "1"
+)
+
+// NewPayload builds a synthetic event payload that satisfies the trigger, as well as possible without on-chain data.
+// Trigger must be validated before calling this, since validation normalizes values.
+func NewPayload(trigger *Trigger, now time.Time) (actions.Payload, error) {
+	if trigger == nil {
+		return actions.Payload{}, errors.New("trigger is not parsed")
+	}
+
+	timestamp := int(now.Unix())
+
+	switch trigger.Type {
+	case PeriodicType:
+		return actions.NewPayloadFromPeriodic(actions.PeriodicPayload{
+			Timestamp: timestamp,
+		}), nil
+	case WebhookType:
+		return actions.NewPayloadFromWebhook(actions.WebhookPayload{
+			Timestamp: timestamp,
+			Body:      make(map[string]interface{}),
+		}), nil
+	case BlockType:
+		return actions.NewPayloadFromBlock(newBlockPayload(trigger.Block)), nil
+	case TransactionType:
+		return actions.NewPayloadFromTransaction(newTransactionPayload(trigger.Transaction)), nil
+	case AlertType:
+		return actions.NewPayloadFromAlert(actions.AlertPayload{
+			AlertId:         "local",
+			Network:         defaultPayloadNetwork,
+			TransactionHash: zeroHash,
+		}), nil
+	}
+
+	return actions.Payload{}, errors.Errorf("unsupported trigger type %s", trigger.Type)
+}
+
+// NewExecutionPayload wraps event payload with storage and secrets, same as the runtime does for deployed actions.
+func NewExecutionPayload(event actions.Payload, secrets map[string]string) actions.ExecutionPayload {
+	if secrets == nil {
+		secrets = make(map[string]string)
+	}
+	return actions.ExecutionPayload{
+		Storage: actions.StoragePayload{
+			Id:    "local",
+			Token: "",
+		},
+		Secrets: actions.SecretsPayload{
+			Secrets: secrets,
+		},
+		Event: event,
+	}
+}
+
+func newBlockPayload(trigger *BlockTrigger) actions.BlockPayload {
+	payload := actions.BlockPayload{
+		Network:     defaultPayloadNetwork,
+		BlockNumber: 0,
+		BlockHash:   zeroHash,
+	}
+	if trigger == nil {
+		return payload
+	}
+
+	if len(trigger.Network.Value.Values) > 0 {
+		payload.Network = trigger.Network.Value.Values[0]
+	}
+	// Block number must be divisible by configured blocks for trigger to fire
+	payload.BlockNumber = trigger.Blocks
+	return payload
+}
+
+func newTransactionPayload(trigger *TransactionTrigger) actions.TransactionPayload {
+	to := zeroAddress
+	payload := actions.TransactionPayload{
+		Network:     defaultPayloadNetwork,
+		BlockHash:   zeroHash,
+		BlockNumber: 0,
+		Hash:        zeroHash,
+		From:        zeroAddress,
+		To:          &to,
+		Logs:        make([]actions.TransactionLog, 0),
+	}
+	if trigger == nil || len(trigger.Filters) == 0 {
+		return payload
+	}
+
+	filter := trigger.Filters[0]
+	if filter.Network != nil && len(filter.Network.Value.Values) > 0 {
+		payload.Network = filter.Network.Value.Values[0]
+	}
+	if filter.From != nil && len(filter.From.Values) > 0 {
+		payload.From = strings.ToLower(filter.From.Values[0].String())
+	}
+	if filter.Contract != nil {
+		to = filter.Contract.Address.String()
+	}
+	if filter.To != nil && len(filter.To.Values) > 0 {
+		to = strings.ToLower(filter.To.Values[0].String())
+	}
+	if filter.Function != nil && len(filter.Function.Values) > 0 {
+		function := filter.Function.Values[0]
+		if function.Contract != nil {
+			to = function.Contract.Address.String()
+		}
+		if function.Signature != nil {
+			input := function.Signature.String()
+			payload.Input = &input
+		}
+	}
+	if filter.EventEmitted != nil {
+		for _, event := range filter.EventEmitted.Values {
+			if event.Id == nil || event.Not {
+				continue
+			}
+			address := to
+			if event.Contract != nil {
+				address = event.Contract.Address.String()
+			}
+			payload.Logs = append(payload.Logs, actions.TransactionLog{
+				Address: address,
+				Topics:  []string{*event.Id},
+				Data:    "0x",
+			})
+		}
+	}
+	if filter.LogEmitted != nil {
+		for _, log := range filter.LogEmitted.Values {
+			if log.Not {
+				continue
+			}
+			address := to
+			if log.Contract != nil {
+				address = log.Contract.Address.String()
+			}
+			topics := make([]string, 0, len(log.StartsWith))
+			for _, topic := range log.StartsWith {
+				topics = append(topics, topic.Value)
+			}
+			payload.Logs = append(payload.Logs, actions.TransactionLog{
+				Address: address,
+				Topics:  topics,
+				Data:    "0x",
+			})
+		}
+	}
+
+	return payload
+}
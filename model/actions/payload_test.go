@@ -0,0 +1,98 @@
+package actions_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+func mustPayloadToMap(t *testing.T, trigger actions.Trigger) map[string]interface{} {
+	payload, err := actions.NewPayload(&trigger, time.Unix(1700000000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ret map[string]interface{}
+	err = json.Unmarshal(content, &ret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestPayloadPeriodic(t *testing.T) {
+	payload := mustPayloadToMap(t, MustReadTriggerAndValidate("trigger_periodic_cron"))
+
+	if payload["type"] != "periodic" {
+		t.Fatalf("expected periodic payload, got %v", payload["type"])
+	}
+	periodic := payload["periodic"].(map[string]interface{})
+	if periodic["timestamp"] != float64(1700000000) {
+		t.Errorf("expected timestamp 1700000000, got %v", periodic["timestamp"])
+	}
+}
+
+func TestPayloadBlock(t *testing.T) {
+	payload := mustPayloadToMap(t, MustReadTriggerAndValidate("trigger_block_simple"))
+
+	if payload["type"] != "block" {
+		t.Fatalf("expected block payload, got %v", payload["type"])
+	}
+	block := payload["block"].(map[string]interface{})
+	if block["network"] != "1" {
+		t.Errorf("expected network 1, got %v", block["network"])
+	}
+}
+
+func TestPayloadTransaction(t *testing.T) {
+	payload := mustPayloadToMap(t, MustReadTriggerAndValidate("trigger_function_signature"))
+
+	if payload["type"] != "transaction" {
+		t.Fatalf("expected transaction payload, got %v", payload["type"])
+	}
+	tx := payload["transaction"].(map[string]interface{})
+	if tx["to"] != "0x13253c152f4d724d15d7b064de106a739551da5f" {
+		t.Errorf("expected to be function contract, got %v", tx["to"])
+	}
+	if tx["input"] != "0x1d6d560f" {
+		t.Errorf("expected input to be function signature, got %v", tx["input"])
+	}
+}
+
+func TestPayloadTransactionLogs(t *testing.T) {
+	payload := mustPayloadToMap(t, MustReadTriggerAndValidate("trigger_transaction_full"))
+
+	tx := payload["transaction"].(map[string]interface{})
+	if tx["from"] != "0xf63c48626f874bf5604d3ba9f4a85d5ce58f8019" {
+		t.Errorf("expected from of the first filter, got %v", tx["from"])
+	}
+	logs := tx["logs"].([]interface{})
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+	topics := logs[0].(map[string]interface{})["topics"].([]interface{})
+	if len(topics) != 2 {
+		t.Errorf("expected 2 topics, got %d", len(topics))
+	}
+}
+
+func TestExecutionPayload(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_webhook_simple")
+	payload, err := actions.NewPayload(&trigger, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	execution := actions.NewExecutionPayload(payload, map[string]string{"API_KEY": "secret"})
+	if execution.Secrets.Secrets["API_KEY"] != "secret" {
+		t.Error("expected secrets to be set")
+	}
+	if _, err := json.Marshal(execution); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,148 @@
+package actions
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+)
+
+type PlanChangeKind string
+
+const (
+	PlanAdd       PlanChangeKind = "add"
+	PlanUpdate    PlanChangeKind = "update"
+	PlanRemove    PlanChangeKind = "remove"
+	PlanUnchanged PlanChangeKind = "unchanged"
+)
+
+// Fields of action spec compared by plan.
+const (
+	PlanFieldTrigger     = "trigger"
+	PlanFieldSource      = "source"
+	PlanFieldRuntime     = "runtime"
+	PlanFieldFunction    = "function"
+	PlanFieldDescription = "description"
+	// Active version is only published, deploy would deploy it
+	PlanFieldStatus = "status"
+)
+
+type ActionPlan struct {
+	Name    string         `json:"name"`
+	Kind    PlanChangeKind `json:"kind"`
+	Changes []string       `json:"changes,omitempty"`
+}
+
+// Plan describes what deploy would change compared to currently active versions.
+type Plan struct {
+	Actions             []ActionPlan `json:"actions"`
+	LogicChanged        bool         `json:"logicChanged"`
+	DependenciesChanged bool         `json:"dependenciesChanged"`
+}
+
+// NewPlan compares local specs (as sent in publish request) with published actions. Logic and dependencies are
+// compared by hash on the backend, so their outcome comes from validate response.
+func NewPlan(
+	local map[string]actions.ActionSpec,
+	remote []actions.Action,
+	logicFound bool,
+	dependenciesFound bool,
+) Plan {
+	plan := Plan{
+		LogicChanged:        !logicFound,
+		DependenciesChanged: !dependenciesFound,
+	}
+
+	remoteByName := make(map[string]actions.Action)
+	for _, action := range remote {
+		remoteByName[action.Name] = action
+	}
+
+	for name, spec := range local {
+		action, exists := remoteByName[name]
+		if !exists {
+			plan.Actions = append(plan.Actions, ActionPlan{Name: name, Kind: PlanAdd})
+			continue
+		}
+
+		changes := diffSpec(spec, action)
+		kind := PlanUnchanged
+		if len(changes) > 0 {
+			kind = PlanUpdate
+		}
+		plan.Actions = append(plan.Actions, ActionPlan{Name: name, Kind: kind, Changes: changes})
+	}
+	for name := range remoteByName {
+		if _, exists := local[name]; !exists {
+			plan.Actions = append(plan.Actions, ActionPlan{Name: name, Kind: PlanRemove})
+		}
+	}
+
+	sort.Slice(plan.Actions, func(i, j int) bool {
+		return plan.Actions[i].Name < plan.Actions[j].Name
+	})
+	return plan
+}
+
+// Count returns number of actions with given kind of change.
+func (p *Plan) Count(kind PlanChangeKind) int {
+	count := 0
+	for _, action := range p.Actions {
+		if action.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// HasChanges reports whether deploy would add or change anything. Actions not in tenderly.yaml are left
+// published by deploy, so they are not changes.
+func (p *Plan) HasChanges() bool {
+	return p.LogicChanged || p.DependenciesChanged || p.Count(PlanAdd) > 0 || p.Count(PlanUpdate) > 0
+}
+
+func diffSpec(spec actions.ActionSpec, action actions.Action) (changes []string) {
+	version := action.Version
+
+	if !equalJSON(spec.Trigger, version.Trigger) {
+		changes = append(changes, PlanFieldTrigger)
+	}
+	if version.Source == nil || spec.Source == nil || *spec.Source != *version.Source {
+		changes = append(changes, PlanFieldSource)
+	}
+	if spec.Runtime.Value() != version.Runtime.Value() {
+		changes = append(changes, PlanFieldRuntime)
+	}
+	if spec.Function != version.Function {
+		changes = append(changes, PlanFieldFunction)
+	}
+	if stringValue(spec.Description) != stringValue(action.Description) {
+		changes = append(changes, PlanFieldDescription)
+	}
+	if action.Status.Value() != actions.DeployStatus_DEPLOYED {
+		changes = append(changes, PlanFieldStatus)
+	}
+	return changes
+}
+
+// equalJSON compares values by their JSON representation, ignoring key order.
+func equalJSON(a interface{}, b interface{}) bool {
+	var aValue, bValue interface{}
+	aContent, errA := json.Marshal(a)
+	bContent, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	if json.Unmarshal(aContent, &aValue) != nil || json.Unmarshal(bContent, &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,120 @@
+package actions_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+)
+
+func newPlanSpec(name string, triggerFile string, source string) generatedActions.ActionSpec {
+	trigger := MustReadTriggerAndValidate(triggerFile)
+	return generatedActions.ActionSpec{
+		Name:        name,
+		Source:      &source,
+		Runtime:     generatedActions.New_Runtime(generatedActions.Runtime_V2),
+		Function:    generatedActions.Function("index:handler"),
+		TriggerType: trigger.ToRequestType(),
+		Trigger:     trigger.ToRequest(),
+	}
+}
+
+func newPlanRemote(spec generatedActions.ActionSpec) generatedActions.Action {
+	return generatedActions.Action{
+		Id:          spec.Name + "-id",
+		Name:        spec.Name,
+		Description: spec.Description,
+		Status:      generatedActions.New_DeployStatus(generatedActions.DeployStatus_DEPLOYED),
+		Version: generatedActions.Version{
+			Runtime:     spec.Runtime,
+			Function:    spec.Function,
+			TriggerType: spec.TriggerType,
+			Trigger:     spec.Trigger,
+			Source:      spec.Source,
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	unchanged := newPlanSpec("unchanged", "trigger_block_simple", "source")
+	triggerChanged := newPlanSpec("triggerChanged", "trigger_periodic_cron", "source")
+	sourceChanged := newPlanSpec("sourceChanged", "trigger_webhook_simple", "new source")
+	added := newPlanSpec("added", "trigger_block_simple", "source")
+
+	remoteTriggerChanged := newPlanRemote(newPlanSpec("triggerChanged", "trigger_periodic_interval", "source"))
+	remoteSourceChanged := newPlanRemote(newPlanSpec("sourceChanged", "trigger_webhook_simple", "old source"))
+	removed := newPlanRemote(newPlanSpec("removed", "trigger_block_simple", "source"))
+
+	plan := actions.NewPlan(
+		map[string]generatedActions.ActionSpec{
+			unchanged.Name:      unchanged,
+			triggerChanged.Name: triggerChanged,
+			sourceChanged.Name:  sourceChanged,
+			added.Name:          added,
+		},
+		[]generatedActions.Action{newPlanRemote(unchanged), remoteTriggerChanged, remoteSourceChanged, removed},
+		true,
+		false,
+	)
+
+	expected := []actions.ActionPlan{
+		{Name: "added", Kind: actions.PlanAdd},
+		{Name: "removed", Kind: actions.PlanRemove},
+		{Name: "sourceChanged", Kind: actions.PlanUpdate, Changes: []string{actions.PlanFieldSource}},
+		{Name: "triggerChanged", Kind: actions.PlanUpdate, Changes: []string{actions.PlanFieldTrigger}},
+		{Name: "unchanged", Kind: actions.PlanUnchanged},
+	}
+	if !reflect.DeepEqual(plan.Actions, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, plan.Actions)
+	}
+	if plan.LogicChanged {
+		t.Error("expected logic to be unchanged when found")
+	}
+	if !plan.DependenciesChanged {
+		t.Error("expected dependencies to be changed when not found")
+	}
+	if plan.Count(actions.PlanUpdate) != 2 {
+		t.Errorf("expected 2 updates, got %d", plan.Count(actions.PlanUpdate))
+	}
+}
+
+func TestPlanNoChanges(t *testing.T) {
+	spec := newPlanSpec("action", "trigger_transaction_full", "source")
+
+	removed := newPlanSpec("removed", "trigger_transaction_full", "source")
+
+	plan := actions.NewPlan(
+		map[string]generatedActions.ActionSpec{spec.Name: spec},
+		[]generatedActions.Action{newPlanRemote(spec), newPlanRemote(removed)},
+		true,
+		true,
+	)
+
+	if plan.HasChanges() {
+		t.Errorf("expected no changes, got %+v", plan)
+	}
+}
+
+func TestPlanPublishedNotDeployed(t *testing.T) {
+	spec := newPlanSpec("action", "trigger_block_simple", "source")
+	published := newPlanRemote(spec)
+	published.Status = generatedActions.New_DeployStatus(generatedActions.DeployStatus_PUBLISHED)
+
+	plan := actions.NewPlan(
+		map[string]generatedActions.ActionSpec{spec.Name: spec},
+		[]generatedActions.Action{published},
+		true,
+		true,
+	)
+
+	expected := []actions.ActionPlan{
+		{Name: "action", Kind: actions.PlanUpdate, Changes: []string{actions.PlanFieldStatus}},
+	}
+	if !reflect.DeepEqual(plan.Actions, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, plan.Actions)
+	}
+	if !plan.HasChanges() {
+		t.Error("expected published action which is not deployed to be a change")
+	}
+}
@@ -0,0 +1,76 @@
+package actions
+
+import (
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Position is location of a node in config file. Line and column start at 1.
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// NodeMap maps path of every node in config file, as ValidatorContext of the node, to its position.
+// Mapping entries are located at their key, sequence items at the item.
+type NodeMap map[ValidatorContext]Position
+
+func NewNodeMap(file string, content []byte) (NodeMap, error) {
+	var root yaml.Node
+	err := yaml.Unmarshal(content, &root)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := make(NodeMap)
+	if len(root.Content) > 0 {
+		nodes.add(file, "", root.Content[0])
+	}
+	return nodes, nil
+}
+
+func (m NodeMap) add(file string, path ValidatorContext, node *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := path.With(key.Value)
+			m[keyPath] = Position{File: file, Line: key.Line, Column: key.Column}
+			m.add(file, keyPath, value)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := path.With(strconv.Itoa(i))
+			m[itemPath] = Position{File: file, Line: item.Line, Column: item.Column}
+			m.add(file, itemPath, item)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			m.add(file, path, node.Alias)
+		}
+	}
+}
+
+// Sub returns nodes under prefix, with paths relative to it.
+func (m NodeMap) Sub(prefix ValidatorContext) NodeMap {
+	sub := make(NodeMap)
+	for path, position := range m {
+		if strings.HasPrefix(string(path), string(prefix)+contextSeparator) {
+			sub[path[len(prefix)+len(contextSeparator):]] = position
+		}
+	}
+	return sub
+}
+
+// Find returns position of path, or of its closest parent if path itself is not in file, e.g. a missing field.
+func (m NodeMap) Find(path ValidatorContext) (Position, bool) {
+	for ; path != ""; path = path.Parent() {
+		if position, exists := m[path]; exists {
+			return position, true
+		}
+	}
+	return Position{}, false
+}
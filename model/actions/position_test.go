@@ -0,0 +1,116 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+var positionTestConfig = []byte(`account_id: ""
+actions:
+  me/proj:
+    runtime: v2
+    specs:
+      hello:
+        function: hello:run
+        trigger:
+          type: transaction
+          transaction:
+            filters:
+              - network: 1
+                from: 0xabc
+      v1.notify:
+        function: notify:run
+        trigger:
+          type: webhook
+`)
+
+func TestNodeMap(t *testing.T) {
+	nodes, err := actions.NewNodeMap("tenderly.yaml", positionTestConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path   []string
+		line   int
+		column int
+	}{
+		{[]string{"actions", "me/proj", "runtime"}, 4, 5},
+		{[]string{"actions", "me/proj", "specs", "hello", "trigger"}, 8, 9},
+		{[]string{"actions", "me/proj", "specs", "hello", "trigger", "transaction", "filters", "0"}, 12, 17},
+		{[]string{"actions", "me/proj", "specs", "hello", "trigger", "transaction", "filters", "0", "from"}, 13, 17},
+		// Missing fields are located at their parent
+		{[]string{"actions", "me/proj", "specs", "hello", "trigger", "transaction", "status"}, 10, 11},
+		// Keys containing dots are single elements
+		{[]string{"actions", "me/proj", "specs", "v1.notify", "trigger", "type"}, 17, 11},
+	}
+	for _, test := range tests {
+		position, found := nodes.Find(actions.NewValidatorContext(test.path...))
+		if !found {
+			t.Errorf("%s not found", test.path)
+			continue
+		}
+		if position.File != "tenderly.yaml" || position.Line != test.line || position.Column != test.column {
+			t.Errorf("%v: expected %d:%d, got %+v", test.path, test.line, test.column, position)
+		}
+	}
+
+	if _, found := nodes.Find(actions.NewValidatorContext("package.json", "dependencies")); found {
+		t.Error("expected path outside of config not to be found")
+	}
+}
+
+func TestDiagnosticsLocate(t *testing.T) {
+	nodes, err := actions.NewNodeMap("tenderly.yaml", positionTestConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs := nodes.Sub(actions.ValidatorContext("actions").With("me/proj").With("specs"))
+
+	var diagnostics actions.Diagnostics
+	diagnostics.Error(
+		actions.NewValidatorContext("hello", "trigger", "transaction", "filters", "0", "from"),
+		"address '0xabc' does not match regex",
+	)
+	diagnostics.Info(actions.NewValidatorContext("other", "trigger"), "not in file")
+	diagnostics.Locate(specs)
+
+	expected := "tenderly.yaml:13:17: address '0xabc' does not match regex"
+	if diagnostics[0].String() != expected {
+		t.Errorf("expected %s, got %s", expected, diagnostics[0].String())
+	}
+	if diagnostics[1].Position != nil || diagnostics[1].String() != "other.trigger: not in file" {
+		t.Errorf("expected diagnostic without position, got %s", diagnostics[1].String())
+	}
+
+	annotations := diagnostics.Annotations()
+	if len(annotations) != 1 {
+		t.Fatalf("expected one annotation, got %d", len(annotations))
+	}
+	if annotations[0].AnnotationLevel != "failure" || annotations[0].StartLine != 13 || annotations[0].Path != "tenderly.yaml" {
+		t.Errorf("unexpected annotation %+v", annotations[0])
+	}
+}
+
+func TestDiagnosticsLocateDottedName(t *testing.T) {
+	nodes, err := actions.NewNodeMap("tenderly.yaml", positionTestConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs := nodes.Sub(actions.ValidatorContext("actions").With("me/proj").With("specs"))
+
+	var response actions.ValidateResponse
+	response.Error(actions.NewValidatorContext("v1.notify", "trigger").With("type"), "unknown type")
+	var diagnostics actions.Diagnostics
+	diagnostics.AddResponse(response)
+	diagnostics.Locate(specs)
+
+	expected := "tenderly.yaml:17:11: unknown type"
+	if diagnostics[0].String() != expected {
+		t.Errorf("expected %s, got %s", expected, diagnostics[0].String())
+	}
+	if diagnostics[0].Path != "v1.notify.trigger.type" {
+		t.Errorf("expected path v1.notify.trigger.type, got %s", diagnostics[0].Path)
+	}
+}
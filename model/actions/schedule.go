@@ -0,0 +1,166 @@
+package actions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Schedules firing more often than this are reported by periodic trigger validation
+var MinScheduleInterval = 5 * time.Minute
+
+// Number of fire times checked for the shortest interval between them
+const scheduleIntervalSamples = 100
+
+var (
+	weekdayNames = []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+	monthNames   = []string{"", "January", "February", "March", "April", "May", "June", "July", "August",
+		"September", "October", "November", "December"}
+)
+
+// Schedule is a cron expression of periodic trigger, evaluated in UTC as by Tenderly.
+type Schedule struct {
+	Cron string
+
+	schedule cron.Schedule
+}
+
+func NewSchedule(expression string) (*Schedule, error) {
+	schedule, err := CronParser.Parse(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &Schedule{Cron: expression, schedule: schedule}, nil
+}
+
+// Next returns up to n fire times in UTC after from. Fewer are returned if schedule stops firing.
+func (s *Schedule) Next(from time.Time, n int) []time.Time {
+	var times []time.Time
+	next := from.UTC()
+	for i := 0; i < n; i++ {
+		next = s.schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		times = append(times, next)
+	}
+	return times
+}
+
+// NeverFires reports whether schedule doesn't fire in five years after from, e.g. on February 30.
+func (s *Schedule) NeverFires(from time.Time) bool {
+	return len(s.Next(from, 1)) == 0
+}
+
+// MinInterval returns the shortest interval between upcoming fire times, zero if it fires less than twice.
+func (s *Schedule) MinInterval(from time.Time) time.Duration {
+	times := s.Next(from, scheduleIntervalSamples)
+	var min time.Duration
+	for i := 1; i < len(times); i++ {
+		interval := times[i].Sub(times[i-1])
+		if min == 0 || interval < min {
+			min = interval
+		}
+	}
+	return min
+}
+
+// Describe returns the schedule in plain English, e.g. "at 09:30, on Monday".
+func (s *Schedule) Describe() string {
+	fields := strings.Fields(s.Cron)
+	if len(fields) != 5 {
+		return s.Cron
+	}
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	var parts []string
+	switch {
+	case isSingleValue(minute) && isSingleValue(hour):
+		h, _ := strconv.Atoi(hour)
+		m, _ := strconv.Atoi(minute)
+		parts = append(parts, fmt.Sprintf("at %02d:%02d", h, m))
+		if dom == "*" && dow == "*" {
+			parts = append(parts, "every day")
+		}
+	case isSingleValue(minute):
+		parts = append(parts, fmt.Sprintf("at minute %s", minute))
+		parts = append(parts, describeField(hour, "every hour", "every %s hours", "during hour %s", nil))
+	default:
+		parts = append(parts, describeField(minute, "every minute", "every %s minutes", "at minutes %s", nil))
+		if hour != "*" {
+			parts = append(parts, describeField(hour, "", "every %s hours", "during hour %s", nil))
+		}
+	}
+
+	var days []string
+	if dom != "*" {
+		days = append(days, describeField(dom, "", "every %s days of the month", "on day %s of the month", nil))
+	}
+	if dow != "*" {
+		days = append(days, describeField(dow, "", "every %s days of the week", "on %s", weekdayNames))
+	}
+	if len(days) > 0 {
+		parts = append(parts, strings.Join(days, " or "))
+	}
+	if month != "*" {
+		parts = append(parts, describeField(month, "", "every %s months", "in %s", monthNames))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// FormatInterval formats interval without zero units, e.g. 5m instead of 5m0s.
+func FormatInterval(interval time.Duration) string {
+	formatted := interval.String()
+	if strings.HasSuffix(formatted, "m0s") {
+		formatted = strings.TrimSuffix(formatted, "0s")
+	}
+	if strings.HasSuffix(formatted, "h0m") {
+		formatted = strings.TrimSuffix(formatted, "0m")
+	}
+	return formatted
+}
+
+func isSingleValue(field string) bool {
+	_, err := strconv.Atoi(field)
+	return err == nil
+}
+
+// describeField describes cron field as "*" with all, "*/n" with step and anything else with values.
+// Numbers are replaced with names if given.
+func describeField(field string, all string, step string, values string, names []string) string {
+	if field == "*" {
+		return all
+	}
+	if strings.HasPrefix(field, "*/") {
+		return fmt.Sprintf(step, strings.TrimPrefix(field, "*/"))
+	}
+
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		bounds := strings.Split(item, "-")
+		for j, bound := range bounds {
+			bounds[j] = fieldValueName(bound, names)
+		}
+		items[i] = strings.Join(bounds, " to ")
+	}
+	return fmt.Sprintf(values, joinList(items))
+}
+
+func fieldValueName(value string, names []string) string {
+	number, err := strconv.Atoi(value)
+	if err != nil || names == nil || number < 0 || number >= len(names) || names[number] == "" {
+		return value
+	}
+	return names[number]
+}
+
+func joinList(items []string) string {
+	if len(items) == 1 {
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " and " + items[len(items)-1]
+}
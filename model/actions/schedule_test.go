@@ -0,0 +1,103 @@
+package actions_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+func TestScheduleNext(t *testing.T) {
+	schedule, err := actions.NewSchedule("30 9 * * 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wednesday
+	from := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	next := schedule.Next(from, 2)
+	expected := []time.Time{
+		time.Date(2024, 5, 20, 9, 30, 0, 0, time.UTC),
+		time.Date(2024, 5, 27, 9, 30, 0, 0, time.UTC),
+	}
+	if len(next) != len(expected) || !next[0].Equal(expected[0]) || !next[1].Equal(expected[1]) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+	if schedule.MinInterval(from) != 7*24*time.Hour {
+		t.Errorf("expected weekly interval, got %s", schedule.MinInterval(from))
+	}
+}
+
+func TestScheduleNeverFires(t *testing.T) {
+	schedule, err := actions.NewSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	if !schedule.NeverFires(from) {
+		t.Error("expected schedule on February 30 to never fire")
+	}
+
+	// Validation doesn't depend on current time, schedule is checked separately
+	trigger := MustReadTriggerAndValidate("trigger_periodic_never_fires")
+	response := trigger.ValidateSchedule("test", from)
+	if len(response.Errors) != 1 || !strings.Contains(response.Errors[0], "test.periodic.cron: ") ||
+		!strings.Contains(response.Errors[0], "never fires") {
+		t.Errorf("expected never fires error, got %v", response.Errors)
+	}
+}
+
+func TestScheduleTooFrequent(t *testing.T) {
+	var trigger actions.PeriodicTrigger
+	cron := "*/2 * * * *"
+	trigger.Cron = &cron
+
+	response := trigger.Validate("test")
+	if len(response.Errors) != 0 || len(response.Infos) != 0 {
+		t.Fatalf("expected no diagnostics, got %v %v", response.Errors, response.Infos)
+	}
+	response = trigger.ValidateSchedule("test", time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC))
+	if len(response.Errors) != 0 {
+		t.Fatalf("expected no errors, got %v", response.Errors)
+	}
+	expected := "test.cron: cron '*/2 * * * *' fires every 2m, more often than every 5m"
+	if len(response.Infos) != 1 || response.Infos[0] != expected {
+		t.Errorf("expected info %q, got %v", expected, response.Infos)
+	}
+}
+
+func TestScheduleDescribe(t *testing.T) {
+	cases := map[string]string{
+		"*/5 * * * *":     "every 5 minutes",
+		"0 * * * *":       "at minute 0, every hour",
+		"0 */2 * * *":     "at minute 0, every 2 hours",
+		"0 0 * * *":       "at 00:00, every day",
+		"30 9 * * 1-5":    "at 09:30, on Monday to Friday",
+		"0 0 1 * *":       "at 00:00, on day 1 of the month",
+		"0 12 1,15 6 *":   "at 12:00, on day 1 and 15 of the month, in June",
+		"*/10 8-17 * * *": "every 10 minutes, during hour 8 to 17",
+	}
+	for cron, expected := range cases {
+		schedule, err := actions.NewSchedule(cron)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if description := schedule.Describe(); description != expected {
+			t.Errorf("%s: expected %q, got %q", cron, expected, description)
+		}
+	}
+}
+
+func TestIntervalsHaveCron(t *testing.T) {
+	for _, interval := range actions.Intervals {
+		cron, ok := actions.IntervalToCron[interval]
+		if !ok {
+			t.Errorf("interval %s has no cron", interval)
+			continue
+		}
+		if _, err := actions.NewSchedule(cron); err != nil {
+			t.Errorf("interval %s: %s", interval, err)
+		}
+	}
+}
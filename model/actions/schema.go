@@ -0,0 +1,200 @@
+package actions
+
+import (
+	"strings"
+
+	"github.com/tenderly/tenderly-cli/jsonschema"
+	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
+)
+
+// Schemas of fields with custom unmarshalling, and constraints which are checked by Validate.
+
+func (s *ProjectActions) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["runtime"] = jsonschema.Enum(SupportedRuntimes...)
+}
+
+func (a *ActionSpec) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["execution_type"] = jsonschema.Enum(SequentialExecutionType, ParallelExecutionType)
+	schema.Required = []string{"function", "trigger"}
+}
+
+// JSONSchema of unparsed trigger is the schema of trigger it is parsed into.
+func (t *TriggerUnparsed) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return g.For(Trigger{})
+}
+
+// JSONSchemaExtend requires trigger configuration matching the trigger type.
+func (a *Trigger) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["type"] = jsonschema.Enum(TriggerTypes...)
+	for _, triggerType := range TriggerTypes {
+		if triggerType == AlertType {
+			continue
+		}
+		schema.AllOf = append(schema.AllOf, &jsonschema.Schema{
+			If: &jsonschema.Schema{
+				Properties: map[string]*jsonschema.Schema{"type": {Const: triggerType}},
+				Required:   []string{"type"},
+			},
+			Then: &jsonschema.Schema{Required: []string{triggerType}},
+		})
+	}
+}
+
+func (t *PeriodicTrigger) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["interval"] = jsonschema.Enum(Intervals...)
+	schema.OneOf = jsonschema.RequireOne("interval", "cron").OneOf
+}
+
+func (t *BlockTrigger) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["blocks"].Minimum = jsonschema.Int(1)
+}
+
+func (t *TransactionTrigger) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["filters"].MinItems = jsonschema.Int(1)
+	schema.Required = append(schema.Required, "filters")
+}
+
+func (c *ContractValue) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["invocation"] = jsonschema.Enum(Invocations...)
+}
+
+// Contract of filter applies to values without account and contract, so neither of them is required in schema.
+
+func (e *EthBalanceValue) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.AllOf = append(schema.AllOf, jsonschema.ForbidTogether("account", "contract"))
+}
+
+func (f *FunctionValue) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.OneOf = jsonschema.RequireOne("signature", "name").OneOf
+	schema.AllOf = append(schema.AllOf, jsonschema.ForbidTogether("signature", "parameters"))
+}
+
+func (e *EventEmittedValue) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["id"] = hexOrInteger("^0x[0-9a-fA-F]+$")
+	schema.OneOf = jsonschema.RequireOne("id", "name").OneOf
+}
+
+func (l *LogEmittedValue) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Properties["startsWith"].MinItems = jsonschema.Int(1)
+	schema.Required = append(schema.Required, "startsWith")
+}
+
+func (r *StateChangedValue) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.OneOf = jsonschema.RequireOne("key", "field").OneOf
+	schema.AllOf = append(schema.AllOf,
+		jsonschema.ForbidTogether("key", "value"),
+		jsonschema.ForbidTogether("key", "previousValue"),
+		jsonschema.ForbidTogether("value", "previousValue"),
+	)
+}
+
+func (v *IntValue) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.AnyOf = jsonschema.RequireOne("gte", "lte", "eq", "gt", "lt").OneOf
+	schema.AllOf = append(schema.AllOf,
+		jsonschema.ForbidTogether("eq", "gte"),
+		jsonschema.ForbidTogether("eq", "lte"),
+		jsonschema.ForbidTogether("eq", "gt"),
+		jsonschema.ForbidTogether("eq", "lt"),
+		jsonschema.ForbidTogether("gte", "gt"),
+		jsonschema.ForbidTogether("lte", "lt"),
+	)
+}
+
+func (s *StrField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(jsonschema.String())
+}
+
+func (i *IntField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(g.For(IntValue{}))
+}
+
+func (a *AddressValue) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	// Addresses are lowercased before validation
+	return hexOrInteger("^0x[0-9a-fA-F]{40}$")
+}
+
+func (a *AddressField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(g.For(AddressValue{}))
+}
+
+// JSONSchema of network accepts chain id as string or number.
+func (n *NetworkField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	chainID := &jsonschema.Schema{Type: []string{"string", "integer"}}
+	return jsonschema.SingleOrList(chainID)
+}
+
+func (s *StatusField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	var statuses []string
+	for _, value := range actions.Status_Values() {
+		statuses = append(statuses, strings.ToLower(string(value)))
+	}
+	return jsonschema.SingleOrList(jsonschema.Enum(statuses...))
+}
+
+func (s *TransactionStatus) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	var statuses []string
+	for _, value := range actions.TransactionStatus_Values() {
+		statuses = append(statuses, strings.ToLower(string(value)))
+	}
+	return jsonschema.SingleOrList(jsonschema.Enum(statuses...))
+}
+
+func (s *SignatureValue) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return hexOrInteger("^0x[0-9a-fA-F]{8}$")
+}
+
+func (h *Hex64) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return hexOrInteger("^0x[0-9a-fA-F]*$")
+}
+
+// JSONSchema of string value accepts plain string or object with exact and not.
+func (v *StrValue) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+		jsonschema.String(),
+		{
+			Type: "object",
+			Properties: map[string]*jsonschema.Schema{
+				"exact": jsonschema.String(),
+				"not":   {Type: "boolean"},
+			},
+			AdditionalProperties: false,
+		},
+	}}
+}
+
+func (a *AnyValue) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+		jsonschema.String(),
+		g.For(MapValue{}),
+		g.For(IntValue{}),
+	}}
+}
+
+func (e *EthBalanceField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(g.For(EthBalanceValue{}))
+}
+
+func (f *FunctionField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(g.For(FunctionValue{}))
+}
+
+func (e *EventEmittedField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(g.For(EventEmittedValue{}))
+}
+
+func (l *LogEmittedField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(g.For(LogEmittedValue{}))
+}
+
+func (s *StateChangedField) JSONSchema(g *jsonschema.Generator) *jsonschema.Schema {
+	return jsonschema.SingleOrList(g.For(StateChangedValue{}))
+}
+
+// hexOrInteger accepts integer too, since editors read unquoted hex as number, while the CLI reads hex which
+// doesn't fit into int64 as string.
+func hexOrInteger(pattern string) *jsonschema.Schema {
+	return &jsonschema.Schema{AnyOf: []*jsonschema.Schema{
+		{Type: "string", Pattern: pattern},
+		jsonschema.Integer(),
+	}}
+}
@@ -0,0 +1,48 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/jsonschema"
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+func TestTriggerSchema(t *testing.T) {
+	g := jsonschema.NewGenerator()
+	spec := g.For(actions.ActionSpec{})
+	if spec.Ref != "#/definitions/ActionSpec" {
+		t.Fatalf("expected reference to ActionSpec, got %q", spec.Ref)
+	}
+	definitions := g.Definitions()
+
+	trigger := definitions["ActionSpec"].Properties["trigger"]
+	if trigger.Ref != "#/definitions/Trigger" {
+		t.Errorf("expected unparsed trigger to reference Trigger, got %q", trigger.Ref)
+	}
+
+	filter := definitions["TransactionFilter"]
+	if filter == nil {
+		t.Fatal("expected TransactionFilter definition")
+	}
+	for _, key := range []string{"network", "from", "value", "function", "eventEmitted", "ethBalance", "stateChanged"} {
+		if filter.Properties[key] == nil {
+			t.Errorf("expected TransactionFilter property %s", key)
+		}
+	}
+	if len(filter.Properties["from"].AnyOf) != 2 {
+		t.Error("expected address field to accept single value or list")
+	}
+
+	periodic := definitions["PeriodicTrigger"]
+	if len(periodic.OneOf) != 2 {
+		t.Errorf("expected exactly one of interval and cron, got %+v", periodic.OneOf)
+	}
+	if len(periodic.Properties["interval"].Enum) != len(actions.Intervals) {
+		t.Error("expected interval enum")
+	}
+
+	block := definitions["BlockTrigger"]
+	if len(block.Required) != 2 {
+		t.Errorf("expected network and blocks to be required, got %v", block.Required)
+	}
+}
@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// Storage value types, matching context.storage getters and setters.
+const (
+	StorageTypeStr    = "str"
+	StorageTypeNumber = "number"
+	StorageTypeBigInt = "bigint"
+	StorageTypeJson   = "json"
+)
+
+var StorageTypes = []string{StorageTypeStr, StorageTypeNumber, StorageTypeBigInt, StorageTypeJson}
+
+// EncodeStorageValue converts value from command line to JSON stored by the runtime. BigInt is stored as decimal
+// string, same as putBigInt does.
+func EncodeStorageValue(value string, valueType string) (json.RawMessage, error) {
+	switch valueType {
+	case StorageTypeStr:
+		return json.Marshal(value)
+	case StorageTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.Errorf("value %s is not a number", value)
+		}
+		return json.Marshal(number)
+	case StorageTypeBigInt:
+		bigInt, ok := new(big.Int).SetString(value, 0)
+		if !ok {
+			return nil, errors.Errorf("value %s is not an integer", value)
+		}
+		return json.Marshal(bigInt.String())
+	case StorageTypeJson:
+		if !json.Valid([]byte(value)) {
+			return nil, errors.Errorf("value %s is not valid JSON", value)
+		}
+		return json.RawMessage(value), nil
+	}
+	return nil, errors.Errorf("storage type %s not supported, supported types %s", valueType, StorageTypes)
+}
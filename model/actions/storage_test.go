@@ -0,0 +1,51 @@
+package actions_test
+
+import (
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+func TestEncodeStorageValue(t *testing.T) {
+	tests := []struct {
+		value     string
+		valueType string
+		expected  string
+	}{
+		{"hello", actions.StorageTypeStr, `"hello"`},
+		{"123", actions.StorageTypeStr, `"123"`},
+		{"15000000", actions.StorageTypeNumber, `15000000`},
+		{"1.5", actions.StorageTypeNumber, `1.5`},
+		{"0xff", actions.StorageTypeBigInt, `"255"`},
+		{"100000000000000000000", actions.StorageTypeBigInt, `"100000000000000000000"`},
+		{`{"block": 1}`, actions.StorageTypeJson, `{"block": 1}`},
+	}
+
+	for _, test := range tests {
+		encoded, err := actions.EncodeStorageValue(test.value, test.valueType)
+		if err != nil {
+			t.Fatalf("%s %s: %s", test.valueType, test.value, err)
+		}
+		if string(encoded) != test.expected {
+			t.Errorf("%s %s: expected %s, got %s", test.valueType, test.value, test.expected, string(encoded))
+		}
+	}
+}
+
+func TestEncodeStorageValueInvalid(t *testing.T) {
+	tests := []struct {
+		value     string
+		valueType string
+	}{
+		{"abc", actions.StorageTypeNumber},
+		{"1.5", actions.StorageTypeBigInt},
+		{"{", actions.StorageTypeJson},
+		{"1", "bool"},
+	}
+
+	for _, test := range tests {
+		if _, err := actions.EncodeStorageValue(test.value, test.valueType); err == nil {
+			t.Errorf("%s %s: expected error", test.valueType, test.value)
+		}
+	}
+}
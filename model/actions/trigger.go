@@ -2,6 +2,7 @@ package actions
 
 import (
 	"strings"
+	"time"
 
 	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
 )
@@ -61,6 +62,14 @@ func (a Trigger) Validate(ctx ValidatorContext) (response ValidateResponse) {
 	panic("Unhandled type in Trigger Validate")
 }
 
+// ValidateSchedule checks upcoming fire times of periodic trigger after now. Must be called after Validate succeeds.
+func (a Trigger) ValidateSchedule(ctx ValidatorContext, now time.Time) (response ValidateResponse) {
+	if a.Periodic == nil {
+		return response
+	}
+	return response.Merge(a.Periodic.ValidateSchedule(ctx.With(PeriodicType), now))
+}
+
 func (a Trigger) ToRequest() *actions.Trigger {
 	if a.Periodic != nil {
 		val := a.Periodic.ToRequest()
@@ -2,6 +2,7 @@ package actions
 
 import (
 	"strings"
+	"time"
 
 	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
 )
@@ -37,17 +38,47 @@ func (t *PeriodicTrigger) Validate(ctx ValidatorContext) (response ValidateRespo
 	if t.Cron != nil {
 		_, err := CronParser.Parse(*t.Cron)
 		if err != nil {
-			return response.Error(ctx, MsgCronNotSupported, t.Cron, err)
+			return response.Error(ctx.With("cron"), MsgCronNotSupported, *t.Cron, err)
 		}
 	}
 
 	return response
 }
 
+// ValidateSchedule checks upcoming fire times of cron after now: schedule that never fires is an error,
+// schedule firing more often than MinScheduleInterval is reported as info. Must be called after Validate succeeds.
+func (t *PeriodicTrigger) ValidateSchedule(ctx ValidatorContext, now time.Time) (response ValidateResponse) {
+	schedule, err := NewSchedule(*t.Cron)
+	if err != nil {
+		return response.Error(ctx.With("cron"), MsgCronNotSupported, *t.Cron, err)
+	}
+
+	if schedule.NeverFires(now) {
+		return response.Error(ctx.With("cron"), MsgCronNeverFires, *t.Cron)
+	}
+	if interval := schedule.MinInterval(now); interval > 0 && interval < MinScheduleInterval {
+		response.Info(ctx.With("cron"), MsgCronTooFrequent, *t.Cron, FormatInterval(interval), FormatInterval(MinScheduleInterval))
+	}
+	return response
+}
+
 func (t *PeriodicTrigger) ToRequest() actions.Trigger {
-	return actions.NewTriggerFromPeriodic(actions.PeriodicTrigger{
+	request := actions.PeriodicTrigger{
 		// cron must be set in validate
-		Cron:     *t.Cron,
-		Interval: t.Interval,
-	})
+		Cron: *t.Cron,
+	}
+	// Other intervals are aliases known only to CLI, they are sent as their cron
+	if t.Interval != nil && isPublishedInterval(*t.Interval) {
+		request.Interval = t.Interval
+	}
+	return actions.NewTriggerFromPeriodic(request)
+}
+
+func isPublishedInterval(interval string) bool {
+	for _, published := range PublishedIntervals {
+		if interval == published {
+			return true
+		}
+	}
+	return false
 }
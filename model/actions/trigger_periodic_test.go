@@ -1,7 +1,11 @@
 package actions_test
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
 )
 
 func TestInterval(t *testing.T) {
@@ -21,5 +25,42 @@ func TestInvalidInterval(t *testing.T) {
 }
 
 func TestInvalidCron(t *testing.T) {
-	_ = MustReadTriggerAndFailValidate("trigger_periodic_invalid_cron")
+	_, response, _ := MustReadTrigger("trigger_periodic_invalid_cron")
+	expected := "test.periodic.cron: cron '* * */abc * *' is not supported"
+	if len(response.Errors) != 1 || !strings.HasPrefix(response.Errors[0], expected) {
+		t.Errorf("expected error %s, got %v", expected, response.Errors)
+	}
+}
+
+func TestIntervalToRequest(t *testing.T) {
+	tests := []struct {
+		interval string
+		cron     string
+		sent     bool
+	}{
+		{"1h", "0 * * * *", true},
+		{"2h", "0 */2 * * *", false},
+		{"weekly", "0 0 * * 1", false},
+		{"monthly", "0 0 1 * *", false},
+	}
+	for _, test := range tests {
+		interval := test.interval
+		trigger := actions.PeriodicTrigger{Interval: &interval}
+		response := trigger.Validate("test")
+		if len(response.Errors) != 0 {
+			t.Fatalf("%s: %v", test.interval, response.Errors)
+		}
+
+		request := trigger.ToRequest()
+		content, err := json.Marshal(&request)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), `"cron":"`+test.cron+`"`) {
+			t.Errorf("%s: expected cron %s, got %s", test.interval, test.cron, content)
+		}
+		if strings.Contains(string(content), `"interval":"`+test.interval+`"`) != test.sent {
+			t.Errorf("%s: expected interval sent %t, got %s", test.interval, test.sent, content)
+		}
+	}
 }
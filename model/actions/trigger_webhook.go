@@ -1,11 +1,15 @@
 package actions
 
 import (
+	"fmt"
+
 	"github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
 )
 
 type WebhookTrigger struct {
 	Authenticated *bool `yaml:"authenticated" json:"authenticated"`
+	// Fields of request body with their typescript types, used only for generated types
+	Body map[string]interface{} `yaml:"body" json:"body,omitempty"`
 }
 
 func (t *WebhookTrigger) Validate(ctx ValidatorContext) (response ValidateResponse) {
@@ -15,6 +19,24 @@ func (t *WebhookTrigger) Validate(ctx ValidatorContext) (response ValidateRespon
 		val := true
 		t.Authenticated = &val
 	}
+	return response.Merge(validateBodyFields(ctx.With("body"), t.Body))
+}
+
+// validateBodyFields checks that every field of body is a typescript type or nested fields.
+// Types are written into generated types of action, so only types of a small grammar are accepted.
+func validateBodyFields(ctx ValidatorContext, fields map[string]interface{}) (response ValidateResponse) {
+	for key, value := range fields {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			response.Merge(validateBodyFields(ctx.With(key), v))
+		case string:
+			if !IsTypescriptType(v) {
+				response.Error(ctx.With(key), MsgBodyFieldTypeInvalid, v)
+			}
+		default:
+			response.Error(ctx.With(key), MsgBodyFieldTypeInvalid, fmt.Sprint(v))
+		}
+	}
 	return response
 }
 
@@ -14,3 +14,30 @@ func TestDefault(t *testing.T) {
 		t.Fatal("did not default correctly")
 	}
 }
+
+func TestWebhookBody(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_webhook_body")
+	if len(trigger.Webhook.Body) != 7 {
+		t.Fatal("body not parsed correctly")
+	}
+}
+
+func TestWebhookInvalidBody(t *testing.T) {
+	_ = MustReadTriggerAndFailValidate("trigger_webhook_invalid_body")
+}
+
+func TestWebhookInvalidBodyType(t *testing.T) {
+	_, response, _ := MustReadTrigger("trigger_webhook_invalid_body_type")
+	if len(response.Errors) != 6 {
+		t.Fatalf("expected 6 errors, got %v", response.Errors)
+	}
+	expected := "test.webhook.body.share: expected typescript type like 'string', 'bigint | string' or 'string[]', " +
+		"or nested fields, got '100%d'"
+	found := false
+	for _, e := range response.Errors {
+		found = found || e == expected
+	}
+	if !found {
+		t.Errorf("expected error %s, got %v", expected, response.Errors)
+	}
+}
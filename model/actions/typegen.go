@@ -0,0 +1,431 @@
+package actions
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// TypesDir is directory in action sources with generated modules, one per action.
+const TypesDir = "tenderly-types"
+
+// Event type passed to action function by trigger type, from @tenderly/actions
+var TriggerEventTypes = map[string]string{
+	PeriodicType:    "PeriodicEvent",
+	WebhookType:     "WebhookEvent",
+	BlockType:       "BlockEvent",
+	TransactionType: "TransactionEvent",
+	AlertType:       "AlertEvent",
+}
+
+var typescriptIdentifierRe = regexp.MustCompile("^[a-zA-Z_$][a-zA-Z0-9_$]*$")
+
+// Tokens of typescript types accepted in webhook body: identifiers, string and number literals and punctuation.
+// String literals can't contain quotes, backslashes or line breaks.
+var typescriptTypeTokenRe = regexp.MustCompile(`^(?:[a-zA-Z_$][a-zA-Z0-9_$]*|'[^'"\\\n\r]*'|"[^'"\\\n\r]*"|-?[0-9]+(?:\.[0-9]+)?|[|&\[\]<>(),.])`)
+
+// Decodes logs by inputs of event, emitted once in module with at least one event decoder.
+// Indexed inputs of reference types are topics with their hash, and are described as bytes32.
+const typesLogDecoder = `interface EventLog {
+	address: string;
+	topics: string[];
+	data: string;
+}
+
+interface AbiInput {
+	name: string;
+	type: string;
+	indexed: boolean;
+}
+
+function decodeLog(log: EventLog, inputs: AbiInput[]): Record<string, unknown> {
+	const args: Record<string, unknown> = {};
+	let topic = 1;
+	let slot = 0;
+	for (const input of inputs) {
+		const value = input.indexed ? log.topics[topic++].slice(2) : word(log.data, slot++);
+		args[input.name] = isDynamic(input.type) ? decodeDynamic(input.type, log.data, value) : decodeWord(input.type, value);
+	}
+	return args;
+}
+
+function word(data: string, index: number): string {
+	return data.slice(2 + index * 64, 2 + (index + 1) * 64);
+}
+
+function isDynamic(type: string): boolean {
+	return type === 'string' || type === 'bytes';
+}
+
+function decodeWord(type: string, value: string): unknown {
+	if (type === 'address') {
+		return '0x' + value.slice(24);
+	}
+	if (type === 'bool') {
+		return BigInt('0x' + value) !== BigInt(0);
+	}
+	if (type.startsWith('uint')) {
+		return BigInt('0x' + value);
+	}
+	if (type.startsWith('int')) {
+		return BigInt.asIntN(Number(type.slice(3)), BigInt('0x' + value));
+	}
+	return '0x' + value.slice(0, Number(type.slice(5)) * 2);
+}
+
+function decodeDynamic(type: string, data: string, offset: string): string {
+	const start = Number(BigInt('0x' + offset)) / 32;
+	const length = Number(BigInt('0x' + word(data, start)));
+	const hex = data.slice(2 + (start + 1) * 64, 2 + (start + 1) * 64 + length * 2);
+	if (type === 'bytes') {
+		return '0x' + hex;
+	}
+	return decodeURIComponent(hex.replace(/(..)/g, '%$1'));
+}
+`
+
+const typesEventDecoder = `export const %[1]sTopic = '%[3]s';
+
+export interface %[2]sArgs {
+%[4]s}
+
+const %[1]sInputs: AbiInput[] = [
+%[5]s];
+
+// decode%[2]s decodes log of %[6]s emitted by contract %[7]s, returns undefined for any other log.
+export function decode%[2]s(log: EventLog): %[2]sArgs | undefined {
+	if (log.address.toLowerCase() !== '%[7]s' || log.topics.length === 0 || log.topics[0].toLowerCase() !== %[1]sTopic) {
+		return undefined;
+	}
+	return decodeLog(log, %[1]sInputs) as unknown as %[2]sArgs;
+}
+
+// find%[2]s decodes every %[8]s log of transaction.
+export function find%[2]s(event: TransactionEvent): %[2]sArgs[] {
+	const found: %[2]sArgs[] = [];
+	for (const log of event.logs) {
+		const args = decode%[2]s(log);
+		if (args !== undefined) {
+			found.push(args);
+		}
+	}
+	return found;
+}
+`
+
+// typesEvent is an event of contract that transaction trigger filters on.
+type typesEvent struct {
+	address string
+	event   abi.Event
+}
+
+// GenerateTypes returns typescript module of action with ActionEvent, the event type narrowed by trigger.
+// Webhook triggers with body get typed payload. Transaction triggers get decoders of events they filter on,
+// for events found in abis. Trigger must be validated.
+func GenerateTypes(actionName string, trigger *Trigger, abis ContractABIs) string {
+	eventType := TriggerEventTypes[trigger.Type]
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("// Code generated by tenderly actions build from trigger of action %s. DO NOT EDIT.\n\n", actionName))
+	b.WriteString(fmt.Sprintf("import { %s } from '@tenderly/actions';\n\n", eventType))
+
+	switch {
+	case trigger.Webhook != nil && len(trigger.Webhook.Body) > 0:
+		b.WriteString(fmt.Sprintf("export interface WebhookBody %s\n\n", typescriptObjectType(trigger.Webhook.Body, "")))
+		b.WriteString(fmt.Sprintf("export type ActionEvent = Omit<%s, 'payload'> & { payload: WebhookBody };\n", eventType))
+	case trigger.Block != nil:
+		b.WriteString(narrowedEventType(eventType, trigger.Block.Network.ToRequest()))
+	case trigger.Transaction != nil:
+		b.WriteString(narrowedEventType(eventType, transactionNetworks(trigger.Transaction)))
+		events := transactionEvents(trigger.Transaction, abis)
+		if len(events) > 0 {
+			b.WriteString("\n" + typesLogDecoder)
+		}
+		for _, event := range typesEventDecoders(events) {
+			b.WriteString("\n" + event)
+		}
+	default:
+		b.WriteString(fmt.Sprintf("export type ActionEvent = %s;\n", eventType))
+	}
+	return b.String()
+}
+
+// narrowedEventType narrows network of event to networks, if trigger is limited to them.
+func narrowedEventType(eventType string, networks []string) string {
+	if len(networks) == 0 {
+		return fmt.Sprintf("export type ActionEvent = %s;\n", eventType)
+	}
+	quoted := make([]string, len(networks))
+	for i, network := range networks {
+		quoted[i] = fmt.Sprintf("'%s'", network)
+	}
+	return fmt.Sprintf("export type ActionEvent = %s & { network: %s };\n", eventType, strings.Join(quoted, " | "))
+}
+
+// transactionNetworks returns networks of all filters, or none if any filter matches every network.
+func transactionNetworks(t *TransactionTrigger) []string {
+	seen := make(map[string]bool)
+	var networks []string
+	for _, filter := range t.Filters {
+		if filter.Network == nil {
+			return nil
+		}
+		for _, network := range filter.Network.ToRequest() {
+			if !seen[network] {
+				seen[network] = true
+				networks = append(networks, network)
+			}
+		}
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+// transactionEvents returns events that filters require to be emitted, as found in abis.
+// Overloaded events matched by name are all returned.
+func transactionEvents(t *TransactionTrigger, abis ContractABIs) (events []typesEvent) {
+	seen := make(map[string]bool)
+	for _, filter := range t.Filters {
+		if filter.EventEmitted == nil {
+			continue
+		}
+		var networks []string
+		if filter.Network != nil {
+			networks = filter.Network.ToRequest()
+		}
+
+		for _, value := range filter.EventEmitted.Values {
+			if value.Not || value.Contract == nil {
+				continue
+			}
+			address := strings.ToLower(value.Contract.Address.String())
+			contractABI := abis.Find(address, networks)
+			if contractABI == nil {
+				continue
+			}
+
+			names := make([]string, 0, len(contractABI.Events))
+			for name := range contractABI.Events {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				event := contractABI.Events[name]
+				matches := (value.Id != nil && strings.ToLower(event.ID.Hex()) == *value.Id) ||
+					(value.Name != nil && event.RawName == *value.Name)
+				key := address + event.ID.Hex()
+				if matches && !seen[key] {
+					seen[key] = true
+					events = append(events, typesEvent{address: address, event: event})
+				}
+			}
+		}
+	}
+	return events
+}
+
+// typesEventDecoders returns decoders of events. Identifiers are made unique with a numeric suffix,
+// as the same event can be emitted by several contracts. Events with inputs that can't be decoded get a comment.
+func typesEventDecoders(events []typesEvent) (decoders []string) {
+	used := make(map[string]bool)
+	for _, e := range events {
+		name := strings.ToUpper(e.event.Name[:1]) + e.event.Name[1:]
+		for i := 2; used[name]; i++ {
+			name = fmt.Sprintf("%s%d", strings.ToUpper(e.event.Name[:1])+e.event.Name[1:], i)
+		}
+		used[name] = true
+
+		var fields, inputs strings.Builder
+		supported := true
+		for i, input := range e.event.Inputs {
+			inputName := input.Name
+			if inputName == "" {
+				inputName = fmt.Sprintf("arg%d", i)
+			}
+			inputType, fieldType, ok := typescriptInputType(input)
+			if !ok {
+				decoders = append(decoders, fmt.Sprintf(
+					"// %s emitted by contract %s is not decoded, input '%s' of type %s is not supported.\n",
+					e.event.Sig, e.address, inputName, input.Type.String(),
+				))
+				supported = false
+				break
+			}
+			fields.WriteString(fmt.Sprintf("\t%s: %s;\n", typescriptKey(inputName), fieldType))
+			inputs.WriteString(fmt.Sprintf("\t{ name: '%s', type: '%s', indexed: %t },\n", inputName, inputType, input.Indexed))
+		}
+		if !supported {
+			continue
+		}
+
+		decoders = append(decoders, fmt.Sprintf(
+			typesEventDecoder,
+			strings.ToLower(name[:1])+name[1:], name, e.event.ID.Hex(),
+			fields.String(), inputs.String(), e.event.Sig, e.address, e.event.RawName,
+		))
+	}
+	return decoders
+}
+
+// typescriptInputType returns type of input as described to log decoder and typescript type of decoded value.
+// Indexed inputs of reference types are hashed into topic, their value is the hash.
+func typescriptInputType(input abi.Argument) (string, string, bool) {
+	switch input.Type.T {
+	case abi.IntTy, abi.UintTy:
+		return input.Type.String(), "bigint", true
+	case abi.BoolTy:
+		return input.Type.String(), "boolean", true
+	case abi.AddressTy, abi.FixedBytesTy:
+		return input.Type.String(), "string", true
+	case abi.StringTy, abi.BytesTy, abi.SliceTy, abi.ArrayTy, abi.TupleTy:
+		if input.Indexed {
+			return "bytes32", "string", true
+		}
+		if input.Type.T == abi.StringTy || input.Type.T == abi.BytesTy {
+			return input.Type.String(), "string", true
+		}
+	}
+	return "", "", false
+}
+
+// typescriptObjectType returns object type with fields of webhook body, nested maps are nested object types.
+func typescriptObjectType(fields map[string]interface{}, indent string) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("{\n")
+	for _, key := range keys {
+		fieldType := fmt.Sprint(fields[key])
+		if nested, ok := fields[key].(map[string]interface{}); ok {
+			fieldType = typescriptObjectType(nested, indent+"\t")
+		}
+		b.WriteString(fmt.Sprintf("%s\t%s: %s;\n", indent, typescriptKey(key), fieldType))
+	}
+	b.WriteString(indent + "}")
+	return b.String()
+}
+
+func typescriptKey(key string) string {
+	if typescriptIdentifierRe.MatchString(key) {
+		return key
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(key, "'", "\\'"))
+}
+
+// IsTypescriptType reports whether typeExpr is a typescript type of a small grammar, which is safe to write
+// into generated module. Accepted are type names, qualified names, string and number literals, unions,
+// intersections, arrays, generic arguments and parentheses, like "bigint | string" or "Array<'a' | 'b'>".
+// Object types, functions and anything else are not accepted.
+func IsTypescriptType(typeExpr string) bool {
+	var tokens []string
+	for rest := strings.TrimSpace(typeExpr); rest != ""; rest = strings.TrimSpace(rest) {
+		token := typescriptTypeTokenRe.FindString(rest)
+		if token == "" {
+			return false
+		}
+		tokens = append(tokens, token)
+		rest = rest[len(token):]
+	}
+
+	p := typescriptTypeParser{tokens: tokens}
+	return p.union() && p.pos == len(tokens)
+}
+
+// typescriptTypeParser parses tokens of a type by grammar:
+//
+//	union        = intersection { "|" intersection }
+//	intersection = array { "&" array }
+//	array        = primary { "[" "]" }
+//	primary      = name [ "<" union { "," union } ">" ] | literal | "(" union ")"
+//	name         = identifier { "." identifier }
+type typescriptTypeParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *typescriptTypeParser) union() bool {
+	if !p.intersection() {
+		return false
+	}
+	for p.accept("|") {
+		if !p.intersection() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *typescriptTypeParser) intersection() bool {
+	if !p.array() {
+		return false
+	}
+	for p.accept("&") {
+		if !p.array() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *typescriptTypeParser) array() bool {
+	if !p.primary() {
+		return false
+	}
+	for p.accept("[") {
+		if !p.accept("]") {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *typescriptTypeParser) primary() bool {
+	if p.pos == len(p.tokens) {
+		return false
+	}
+	token := p.tokens[p.pos]
+	switch {
+	case token == "(":
+		p.pos++
+		return p.union() && p.accept(")")
+	case typescriptIdentifierRe.MatchString(token):
+		p.pos++
+		for p.accept(".") {
+			if p.pos == len(p.tokens) || !typescriptIdentifierRe.MatchString(p.tokens[p.pos]) {
+				return false
+			}
+			p.pos++
+		}
+		if p.accept("<") {
+			if !p.union() {
+				return false
+			}
+			for p.accept(",") {
+				if !p.union() {
+					return false
+				}
+			}
+			return p.accept(">")
+		}
+		return true
+	case strings.ContainsAny(token[:1], `'"-0123456789`):
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *typescriptTypeParser) accept(token string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos] == token {
+		p.pos++
+		return true
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package actions_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/model/actions"
+)
+
+func assertContains(t *testing.T, content string, expected ...string) {
+	t.Helper()
+	for _, e := range expected {
+		if !strings.Contains(content, e) {
+			t.Errorf("expected to contain:\n%s\ngot:\n%s", e, content)
+		}
+	}
+}
+
+func TestGenerateTypesEvents(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_typegen_events")
+	abis := mustERC20ABIs(t, "1")
+	err := abis.Add("5", "0xFc4c08972fa997C447982D634b0B48C554d92CEe", []byte(erc20ABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := actions.GenerateTypes("my-action", &trigger, abis)
+	assertContains(t, content,
+		"import { TransactionEvent } from '@tenderly/actions';",
+		"export type ActionEvent = TransactionEvent & { network: '1' | '5' };",
+		"export const transferTopic = '0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef';",
+		"export interface TransferArgs {\n\tfrom: string;\n\tto: string;\n\tvalue: bigint;\n}",
+		"{ name: 'value', type: 'uint256', indexed: false },",
+		"log.address.toLowerCase() !== '0x13253c152f4d724d15d7b064de106a739551da5f'",
+		"export function findTransfer2(event: TransactionEvent): Transfer2Args[] {",
+		"log.address.toLowerCase() !== '0xfc4c08972fa997c447982d634b0b48c554d92cee'",
+	)
+	if strings.Contains(content, "Approval") {
+		t.Error("expected no decoder of event which must not be emitted")
+	}
+}
+
+func TestGenerateTypesWithoutABI(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_typegen_events")
+
+	content := actions.GenerateTypes("my-action", &trigger, actions.ContractABIs{})
+	if strings.Contains(content, "decodeLog") {
+		t.Errorf("expected no decoders without abi, got:\n%s", content)
+	}
+}
+
+func TestGenerateTypesWebhookBody(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_webhook_body")
+
+	content := actions.GenerateTypes("my-action", &trigger, nil)
+	assertContains(t, content,
+		"export interface WebhookBody {\n\taddress: string;\n\tamount: bigint | string;\n\tmeta: {\n\t\ttags: string[];\n\t};\n"+
+			"\tpairs: (string | 0)[];\n\tstatus: 'pending' | 'done';\n\t'user-agent': string;\n\tvalues: Array<number>;\n}",
+		"export type ActionEvent = Omit<WebhookEvent, 'payload'> & { payload: WebhookBody };",
+	)
+}
+
+func TestGenerateTypesBlock(t *testing.T) {
+	trigger := MustReadTriggerAndValidate("trigger_block_list")
+
+	content := actions.GenerateTypes("my-action", &trigger, nil)
+	assertContains(t, content, "export type ActionEvent = BlockEvent & { network: '1' | '42' };")
+}
@@ -2,12 +2,39 @@ package actions
 
 import (
 	"fmt"
+	"strings"
 )
 
+// ValidatorContext is path of validated node, e.g. myAction.trigger.periodic. Its elements are kept apart,
+// so names containing dots, like action or file names, stay single elements when the path is located.
 type ValidatorContext string
 
+// Separates elements of ValidatorContext, doesn't appear in YAML keys or file names
+const contextSeparator = "\x00"
+
+func NewValidatorContext(elements ...string) ValidatorContext {
+	return ValidatorContext(strings.Join(elements, contextSeparator))
+}
+
 func (c ValidatorContext) With(element string) ValidatorContext {
-	return ValidatorContext(fmt.Sprintf("%s.%s", c, element))
+	if c == "" {
+		return ValidatorContext(element)
+	}
+	return c + ValidatorContext(contextSeparator+element)
+}
+
+// Parent returns context without its last element, empty if it has a single element.
+func (c ValidatorContext) Parent() ValidatorContext {
+	index := strings.LastIndex(string(c), contextSeparator)
+	if index < 0 {
+		return ""
+	}
+	return c[:index]
+}
+
+// String returns path with elements separated by dots.
+func (c ValidatorContext) String() string {
+	return strings.ReplaceAll(string(c), contextSeparator, ".")
 }
 
 type Validator interface {
@@ -17,28 +44,40 @@ type Validator interface {
 type ValidateResponse struct {
 	Infos  []string
 	Errors []string
+
+	// Same messages with the context they are about, in the same order, used to locate them in config file
+	infos  []validateMessage
+	errors []validateMessage
+}
+
+type validateMessage struct {
+	ctx ValidatorContext
+	msg string
 }
 
 func (v *ValidateResponse) Info(c ValidatorContext, msg string, args ...interface{}) ValidateResponse {
-	v.Infos = append(v.Infos, format(c, msg, args...))
+	message := validateMessage{ctx: c, msg: fmt.Sprintf(msg, args...)}
+	v.Infos = append(v.Infos, message.String())
+	v.infos = append(v.infos, message)
 	return *v
 }
 
 func (v *ValidateResponse) Error(c ValidatorContext, msg string, args ...interface{}) ValidateResponse {
-	v.Errors = append(v.Errors, format(c, msg, args...))
+	message := validateMessage{ctx: c, msg: fmt.Sprintf(msg, args...)}
+	v.Errors = append(v.Errors, message.String())
+	v.errors = append(v.errors, message)
 	return *v
 }
 
-func format(c ValidatorContext, msg string, args ...interface{}) string {
-	return fmt.Sprintf("%s: %s", c, fmt.Sprintf(msg, args...))
+// String returns message with its context. Message is already formatted and can contain '%' from user values.
+func (m validateMessage) String() string {
+	return fmt.Sprintf("%s: %s", m.ctx, m.msg)
 }
 
 func (v *ValidateResponse) Merge(response ValidateResponse) ValidateResponse {
-	for _, msg := range response.Errors {
-		v.Errors = append(v.Errors, msg)
-	}
-	for _, msg := range response.Infos {
-		v.Infos = append(v.Infos, msg)
-	}
+	v.Errors = append(v.Errors, response.Errors...)
+	v.errors = append(v.errors, response.errors...)
+	v.Infos = append(v.Infos, response.Infos...)
+	v.infos = append(v.infos, response.infos...)
 	return *v
 }
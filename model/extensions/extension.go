@@ -1,5 +1,7 @@
 package extensions
 
+import "github.com/tenderly/tenderly-cli/jsonschema"
+
 type BackendExtension struct {
 	Name     string `json:"name" yaml:"name"`
 	Method   string `json:"methodName" yaml:"method"`
@@ -16,3 +18,7 @@ type ConfigExtension struct {
 type ConfigProjectExtensions struct {
 	Specs map[string]*ConfigExtension `json:"specs" yaml:"specs"`
 }
+
+func (e *ConfigExtension) JSONSchemaExtend(schema *jsonschema.Schema) {
+	schema.Required = []string{"method", "action"}
+}
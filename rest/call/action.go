@@ -3,6 +3,7 @@ package call
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/tenderly/tenderly-cli/config"
@@ -39,18 +40,24 @@ func (e *maybeErrorResponse) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
-func (rest *ActionCalls) Validate(request actions2.ValidateRequest, projectSlug string) (*actions2.ValidateResponse, error) {
-	uploadJson, err := json.Marshal(request)
-	if err != nil {
-		return nil, err
-	}
-
+// splitProjectSlug resolves account from "account/project" slug, or falls back to the logged in account.
+func splitProjectSlug(projectSlug string) (string, string) {
 	accountID := config.GetGlobalString(config.AccountID)
 	if strings.Contains(projectSlug, "/") {
 		projectInfo := strings.Split(projectSlug, "/")
 		accountID = projectInfo[0]
 		projectSlug = projectInfo[1]
 	}
+	return accountID, projectSlug
+}
+
+func (rest *ActionCalls) Validate(request actions2.ValidateRequest, projectSlug string) (*actions2.ValidateResponse, error) {
+	uploadJson, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, projectSlug := splitProjectSlug(projectSlug)
 
 	retOrError := maybeErrorResponse{}
 	ret := actions2.ValidateResponse{}
@@ -80,12 +87,7 @@ func (rest *ActionCalls) Publish(request actions2.PublishRequest, projectSlug st
 		return nil, err
 	}
 
-	accountID := config.GetGlobalString(config.AccountID)
-	if strings.Contains(projectSlug, "/") {
-		projectInfo := strings.Split(projectSlug, "/")
-		accountID = projectInfo[0]
-		projectSlug = projectInfo[1]
-	}
+	accountID, projectSlug := splitProjectSlug(projectSlug)
 
 	retOrError := maybeErrorResponse{}
 	ret := actions2.PublishResponse{}
@@ -132,3 +134,281 @@ func (rest *ActionCalls) GetActionsForExtensions(accountSlugOrID string, project
 
 	return &ret, err
 }
+
+func (rest *ActionCalls) GetActions(projectSlug string) (*payloads.GetActionsResponse, error) {
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := payloads.GetActionsResponse{}
+
+	response := client.Request(
+		"GET",
+		"api/v1/account/"+accountID+"/project/"+projectSlug+"/actions",
+		nil,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) GetCalls(projectSlug string, actionID string, limit int) (*payloads.GetCallsResponse, error) {
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := payloads.GetCallsResponse{}
+
+	path := fmt.Sprintf("api/v1/account/%s/project/%s/action/%s/calls?limit=%d", accountID, projectSlug, actionID, limit)
+	response := client.Request(
+		"GET",
+		path,
+		nil,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) GetCall(projectSlug string, actionID string, callID string) (*payloads.GetCallResponse, error) {
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := payloads.GetCallResponse{}
+
+	path := fmt.Sprintf("api/v1/account/%s/project/%s/action/%s/call/%s", accountID, projectSlug, actionID, callID)
+	response := client.Request(
+		"GET",
+		path,
+		nil,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) Stop(request actions2.StopRequest, projectSlug string) error {
+	return rest.requestWithoutResponse("POST", projectSlug, "actions/stop", request)
+}
+
+func (rest *ActionCalls) Resume(request actions2.ResumeRequest, projectSlug string) error {
+	return rest.requestWithoutResponse("POST", projectSlug, "actions/resume", request)
+}
+
+// requestWithoutResponse calls project scoped route which returns nothing but an error.
+func (rest *ActionCalls) requestWithoutResponse(method string, projectSlug string, route string, request interface{}) error {
+	var uploadJson []byte
+	if request != nil {
+		var err error
+		uploadJson, err = json.Marshal(request)
+		if err != nil {
+			return err
+		}
+	}
+
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+
+	response := client.Request(
+		method,
+		"api/v1/account/"+accountID+"/project/"+projectSlug+"/"+route,
+		uploadJson,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	return nil
+}
+
+func (rest *ActionCalls) GetVersions(projectSlug string, actionID string) (*payloads.GetVersionsResponse, error) {
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := payloads.GetVersionsResponse{}
+
+	path := fmt.Sprintf("api/v1/account/%s/project/%s/action/%s/versions", accountID, projectSlug, actionID)
+	response := client.Request(
+		"GET",
+		path,
+		nil,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) Deploy(request actions2.DeployRequest, projectSlug string, actionID string) (*actions2.DeployResponse, error) {
+	uploadJson, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := actions2.DeployResponse{}
+
+	path := fmt.Sprintf("api/v1/account/%s/project/%s/action/%s/deploy", accountID, projectSlug, actionID)
+	response := client.Request(
+		"POST",
+		path,
+		uploadJson,
+	)
+
+	err = json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+	// Deploy response is empty for now
+	if len(retOrError.Data) == 0 {
+		return &ret, nil
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) GetSecrets(projectSlug string) (*payloads.GetSecretsResponse, error) {
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := payloads.GetSecretsResponse{}
+
+	response := client.Request(
+		"GET",
+		"api/v1/account/"+accountID+"/project/"+projectSlug+"/actions/secrets",
+		nil,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) SetSecrets(request payloads.SetSecretsRequest, projectSlug string) error {
+	return rest.requestWithoutResponse("PUT", projectSlug, "actions/secrets", request)
+}
+
+func (rest *ActionCalls) DeleteSecret(name string, projectSlug string) error {
+	return rest.requestWithoutResponse("DELETE", projectSlug, "actions/secret/"+url.PathEscape(name), nil)
+}
+
+// storageRoute addresses project storage, or storage with given id (e.g. storage of a single execution).
+func storageRoute(storageID string, key *string) string {
+	route := "actions/storage"
+	if key != nil {
+		route += "/" + url.PathEscape(*key)
+	}
+	if storageID != "" {
+		route += "?storageId=" + url.QueryEscape(storageID)
+	}
+	return route
+}
+
+func (rest *ActionCalls) GetStorage(projectSlug string, storageID string) (*payloads.GetStorageResponse, error) {
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := payloads.GetStorageResponse{}
+
+	response := client.Request(
+		"GET",
+		"api/v1/account/"+accountID+"/project/"+projectSlug+"/"+storageRoute(storageID, nil),
+		nil,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) GetStorageValue(projectSlug string, storageID string, key string) (*payloads.GetStorageValueResponse, error) {
+	accountID, projectSlug := splitProjectSlug(projectSlug)
+
+	retOrError := maybeErrorResponse{}
+	ret := payloads.GetStorageValueResponse{}
+
+	response := client.Request(
+		"GET",
+		"api/v1/account/"+accountID+"/project/"+projectSlug+"/"+storageRoute(storageID, &key),
+		nil,
+	)
+
+	err := json.NewDecoder(response).Decode(&retOrError)
+	if err == nil && retOrError.Error != nil {
+		return nil, fmt.Errorf("%s (%s)", retOrError.Error.Message, retOrError.Error.Slug)
+	}
+
+	err = json.Unmarshal(retOrError.Data, &ret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret, err
+}
+
+func (rest *ActionCalls) PutStorage(request payloads.PutStorageRequest, projectSlug string, storageID string) error {
+	return rest.requestWithoutResponse("PUT", projectSlug, storageRoute(storageID, nil), request)
+}
+
+func (rest *ActionCalls) DeleteStorageValue(projectSlug string, storageID string, key string) error {
+	return rest.requestWithoutResponse("DELETE", projectSlug, storageRoute(storageID, &key), nil)
+}
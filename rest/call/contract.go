@@ -20,9 +20,16 @@ func NewContractCalls() *ContractCalls {
 func (rest *ContractCalls) GetContracts(projectSlug string) (*payloads.GetContractsResponse, error) {
 	var contracts payloads.GetContractsResponse
 
+	accountID := "me"
+	if strings.Contains(projectSlug, "/") {
+		projectInfo := strings.Split(projectSlug, "/")
+		accountID = projectInfo[0]
+		projectSlug = projectInfo[1]
+	}
+
 	response := client.Request(
 		"GET",
-		fmt.Sprintf("api/v1/account/me/project/%s/contracts?accountType=contract", projectSlug),
+		fmt.Sprintf("api/v1/account/%s/project/%s/contracts?accountType=contract", accountID, projectSlug),
 		nil,
 	)
 
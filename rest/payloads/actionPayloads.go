@@ -1,9 +1,51 @@
 package payloads
 
 import (
+	"encoding/json"
+
 	"github.com/tenderly/tenderly-cli/model/actions"
+	generatedActions "github.com/tenderly/tenderly-cli/rest/payloads/generated/actions"
 )
 
 type GetActionsForExtensionsResponse struct {
 	Actions []actions.Action
 }
+
+type GetActionsResponse struct {
+	Actions []generatedActions.Action `json:"actions"`
+}
+
+type GetCallsResponse struct {
+	Calls []generatedActions.CallSummary `json:"calls"`
+}
+
+type GetCallResponse struct {
+	Call generatedActions.Call `json:"call"`
+}
+
+type GetVersionsResponse struct {
+	Versions []generatedActions.Version `json:"versions"`
+}
+
+type GetSecretsResponse struct {
+	Secrets map[string]string `json:"secrets"`
+}
+
+// SetSecretsRequest creates or overwrites secrets, other secrets in project are kept.
+type SetSecretsRequest struct {
+	Secrets map[string]string `json:"secrets"`
+}
+
+// GetStorageResponse contains all values in storage, as JSON stored by the runtime.
+type GetStorageResponse struct {
+	Storage map[string]json.RawMessage `json:"storage"`
+}
+
+type GetStorageValueResponse struct {
+	Value json.RawMessage `json:"value"`
+}
+
+// PutStorageRequest creates or overwrites values, other keys in storage are kept.
+type PutStorageRequest struct {
+	Values map[string]json.RawMessage `json:"values"`
+}
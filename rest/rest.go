@@ -38,6 +38,20 @@ type ActionRoutes interface {
 	GetActionsForExtensions(accountSlugOrID string, projectSlugOrID string) (*payloads.GetActionsForExtensionsResponse, error)
 	Validate(request generatedActions.ValidateRequest, projectSlug string) (*generatedActions.ValidateResponse, error)
 	Publish(request generatedActions.PublishRequest, projectSlug string) (*generatedActions.PublishResponse, error)
+	GetActions(projectSlug string) (*payloads.GetActionsResponse, error)
+	GetCalls(projectSlug string, actionID string, limit int) (*payloads.GetCallsResponse, error)
+	GetCall(projectSlug string, actionID string, callID string) (*payloads.GetCallResponse, error)
+	Stop(request generatedActions.StopRequest, projectSlug string) error
+	Resume(request generatedActions.ResumeRequest, projectSlug string) error
+	GetVersions(projectSlug string, actionID string) (*payloads.GetVersionsResponse, error)
+	Deploy(request generatedActions.DeployRequest, projectSlug string, actionID string) (*generatedActions.DeployResponse, error)
+	GetSecrets(projectSlug string) (*payloads.GetSecretsResponse, error)
+	SetSecrets(request payloads.SetSecretsRequest, projectSlug string) error
+	DeleteSecret(name string, projectSlug string) error
+	GetStorage(projectSlug string, storageID string) (*payloads.GetStorageResponse, error)
+	GetStorageValue(projectSlug string, storageID string, key string) (*payloads.GetStorageValueResponse, error)
+	PutStorage(request payloads.PutStorageRequest, projectSlug string, storageID string) error
+	DeleteStorageValue(projectSlug string, storageID string, key string) error
 }
 
 type DevNetRoutes interface {
@@ -6,6 +6,8 @@ const (
 	GitIgnoreFile       = ".gitignore"
 	PackageJsonFile     = "package.json"
 	PackageJsonLockFile = "package-lock.json"
+	YarnLockFile        = "yarn.lock"
+	PnpmLockFile        = "pnpm-lock.yaml"
 
 	NodeModulesDir = "node_modules"
 
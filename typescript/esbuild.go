@@ -0,0 +1,30 @@
+package typescript
+
+import (
+	"os/exec"
+	"path/filepath"
+)
+
+const EsbuildPackage = "esbuild"
+
+// EsbuildPath is path of esbuild binary installed as dependency of the project in directory.
+func EsbuildPath(directory string) string {
+	return filepath.Join(directory, NodeModulesDir, ".bin", EsbuildPackage)
+}
+
+// BundleCommand bundles every entrypoint into a single commonjs file in outDir, keeping its path relative to baseDir.
+// Modules which entrypoint doesn't reach are left out.
+func BundleCommand(directory string, entrypoints []string, baseDir string, outDir string, target string) *exec.Cmd {
+	args := append([]string{}, entrypoints...)
+	args = append(args,
+		"--bundle",
+		"--tree-shaking=true",
+		"--platform=node",
+		"--format=cjs",
+		"--target="+target,
+		"--outbase="+baseDir,
+		"--outdir="+outDir,
+		"--log-level=warning",
+	)
+	return exec.Command(EsbuildPath(directory), args...)
+}
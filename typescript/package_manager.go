@@ -0,0 +1,97 @@
+package typescript
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// PackageManager runs install and build of a node project. Commands are run from outside the project,
+// the project directory is passed with DirFlag.
+type PackageManager struct {
+	Name     string
+	LockFile string
+	DirFlag  string
+	// Extra arguments of install command
+	InstallArgs []string
+}
+
+var (
+	Npm  = PackageManager{Name: "npm", LockFile: PackageJsonLockFile, DirFlag: "--prefix", InstallArgs: []string{"--verbose"}}
+	Yarn = PackageManager{Name: "yarn", LockFile: YarnLockFile, DirFlag: "--cwd"}
+	Pnpm = PackageManager{Name: "pnpm", LockFile: PnpmLockFile, DirFlag: "--dir"}
+)
+
+// PackageManagers in order of precedence when lock files of several of them exist in the same directory.
+var PackageManagers = []PackageManager{Pnpm, Yarn, Npm}
+
+func PackageManagerNames() []string {
+	names := make([]string, 0, len(PackageManagers))
+	for _, manager := range PackageManagers {
+		names = append(names, manager.Name)
+	}
+	return names
+}
+
+func GetPackageManager(name string) (PackageManager, error) {
+	for _, manager := range PackageManagers {
+		if manager.Name == name {
+			return manager, nil
+		}
+	}
+	return PackageManager{}, fmt.Errorf(
+		"unsupported package manager %s, supported values {%s}", name, strings.Join(PackageManagerNames(), ","),
+	)
+}
+
+// DetectPackageManager looks for a lock file in directory and its parents, so projects inside of workspaces
+// use the package manager of the workspace. Defaults to npm if there is no lock file.
+func DetectPackageManager(directory string) PackageManager {
+	manager, _, found := FindLockFile(directory)
+	if !found {
+		return Npm
+	}
+	return manager
+}
+
+// FindLockFile returns the closest lock file in directory or its parents, and package manager which wrote it.
+func FindLockFile(directory string) (PackageManager, string, bool) {
+	dir, err := filepath.Abs(directory)
+	if err != nil {
+		return PackageManager{}, "", false
+	}
+	for {
+		for _, manager := range PackageManagers {
+			path := filepath.Join(dir, manager.LockFile)
+			if fileExists(path) {
+				return manager, path, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return PackageManager{}, "", false
+		}
+		dir = parent
+	}
+}
+
+func (p PackageManager) InstallCommand(directory string) *exec.Cmd {
+	args := append([]string{p.DirFlag, directory, "install"}, p.InstallArgs...)
+	return exec.Command(p.Name, args...)
+}
+
+func (p PackageManager) RunCommand(directory string, script string) *exec.Cmd {
+	return exec.Command(p.Name, p.DirFlag, directory, "run", script)
+}
+
+// CommandString is command as it would be typed in shell, used in messages.
+func CommandString(cmd *exec.Cmd) string {
+	return strings.Join(cmd.Args, " ")
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
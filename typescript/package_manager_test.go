@@ -0,0 +1,53 @@
+package typescript_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tenderly/tenderly-cli/typescript"
+)
+
+func TestDetectPackageManager(t *testing.T) {
+	root := t.TempDir()
+	sources := filepath.Join(root, "packages", "actions")
+	err := os.MkdirAll(sources, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Lock file of the workspace root is used by projects inside of it
+	writeFile(t, filepath.Join(root, typescript.PnpmLockFile))
+	if manager := typescript.DetectPackageManager(sources); manager.Name != "pnpm" {
+		t.Errorf("expected pnpm, got %s", manager.Name)
+	}
+
+	// Closest lock file wins
+	writeFile(t, filepath.Join(sources, typescript.YarnLockFile))
+	if manager := typescript.DetectPackageManager(sources); manager.Name != "yarn" {
+		t.Errorf("expected yarn, got %s", manager.Name)
+	}
+}
+
+func TestGetPackageManager(t *testing.T) {
+	manager, err := typescript.GetPackageManager("pnpm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "pnpm --dir actions run build"
+	if command := typescript.CommandString(manager.RunCommand("actions", "build")); command != expected {
+		t.Errorf("expected %s, got %s", expected, command)
+	}
+
+	_, err = typescript.GetPackageManager("bun")
+	if err == nil {
+		t.Error("expected error for unsupported package manager")
+	}
+}
+
+func writeFile(t *testing.T, path string) {
+	err := os.WriteFile(path, []byte{}, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,96 @@
+package zip
+
+import (
+	"archive/zip"
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const nodeModulesPath = "node_modules/"
+
+// Size is size of a file, or of all files of a package, inside zip.
+type Size struct {
+	Name              string `json:"name"`
+	Files             int    `json:"files"`
+	CompressedBytes   uint64 `json:"compressedBytes"`
+	UncompressedBytes uint64 `json:"uncompressedBytes"`
+}
+
+// Analysis lists what zip size consists of, largest first.
+type Analysis struct {
+	Bytes int    `json:"bytes"`
+	Files []Size `json:"files,omitempty"`
+	// Packages groups files by top level node_modules package they belong to, including its nested dependencies.
+	Packages []Size `json:"packages,omitempty"`
+}
+
+func Analyze(content []byte) (*Analysis, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.Wrap(err, "read zip")
+	}
+
+	analysis := &Analysis{Bytes: len(content)}
+	packages := make(map[string]*Size)
+	for _, file := range reader.File {
+		size := Size{
+			Name:              file.Name,
+			Files:             1,
+			CompressedBytes:   file.CompressedSize64,
+			UncompressedBytes: file.UncompressedSize64,
+		}
+		analysis.Files = append(analysis.Files, size)
+
+		name, ok := packageName(file.Name)
+		if !ok {
+			continue
+		}
+		pkg, exists := packages[name]
+		if !exists {
+			pkg = &Size{Name: name}
+			packages[name] = pkg
+		}
+		pkg.Files++
+		pkg.CompressedBytes += size.CompressedBytes
+		pkg.UncompressedBytes += size.UncompressedBytes
+	}
+	for _, pkg := range packages {
+		analysis.Packages = append(analysis.Packages, *pkg)
+	}
+
+	sortBySize(analysis.Files)
+	sortBySize(analysis.Packages)
+	return analysis, nil
+}
+
+// packageName returns name of the top level package file is in, e.g. @ethersproject/abi for
+// nodejs/node_modules/@ethersproject/abi/lib/index.js.
+func packageName(path string) (string, bool) {
+	index := strings.Index(path, nodeModulesPath)
+	if index < 0 {
+		return "", false
+	}
+	parts := strings.Split(path[index+len(nodeModulesPath):], "/")
+	if len(parts) < 2 {
+		return "", false
+	}
+	if strings.HasPrefix(parts[0], "@") {
+		if len(parts) < 3 {
+			return "", false
+		}
+		return parts[0] + "/" + parts[1], true
+	}
+	return parts[0], true
+}
+
+func sortBySize(sizes []Size) {
+	sort.SliceStable(sizes, func(i, j int) bool {
+		if sizes[i].CompressedBytes != sizes[j].CompressedBytes {
+			return sizes[i].CompressedBytes > sizes[j].CompressedBytes
+		}
+		return sizes[i].Name < sizes[j].Name
+	})
+}
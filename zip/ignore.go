@@ -0,0 +1,72 @@
+package zip
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/pkg/errors"
+)
+
+const IgnoreFile = ".tenderlyignore"
+
+// Ignore excludes files from zip using gitignore patterns, matched against paths relative to Root.
+type Ignore struct {
+	Root    string
+	matcher gitignore.Matcher
+}
+
+// LoadIgnore reads IgnoreFile from root. Returns nil if it doesn't exist, nil Ignore doesn't exclude anything.
+func LoadIgnore(root string) (*Ignore, error) {
+	content, err := os.ReadFile(filepath.Join(root, IgnoreFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed reading %s", IgnoreFile))
+	}
+	return NewIgnore(root, content), nil
+}
+
+func NewIgnore(root string, content []byte) *Ignore {
+	var patterns []gitignore.Pattern
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, gitignore.ParsePattern(line, nil))
+	}
+
+	return &Ignore{
+		Root:    root,
+		matcher: gitignore.NewMatcher(patterns),
+	}
+}
+
+// Match reports if path should be left out of zip. Paths outside of Root are never excluded.
+func (i *Ignore) Match(path string, isDir bool) bool {
+	if i == nil {
+		return false
+	}
+
+	root, err := filepath.Abs(i.Root)
+	if err != nil {
+		return false
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	return i.matcher.Match(strings.Split(filepath.ToSlash(rel), "/"), isDir)
+}
@@ -0,0 +1,93 @@
+package zip
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Manifest lists every file inside zip with SHA-256 of its content. Unlike zip bytes, it doesn't depend on
+// compression or file metadata, so the same files have the same manifest on every machine.
+type Manifest struct {
+	Entries []ManifestEntry
+}
+
+type ManifestEntry struct {
+	Path   string
+	SHA256 string
+}
+
+func NewManifest(content []byte) (*Manifest, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.Wrap(err, "read zip")
+	}
+
+	manifest := &Manifest{}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		hash, err := hashFile(file)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("hash %s", file.Name))
+		}
+		manifest.Entries = append(manifest.Entries, ManifestEntry{Path: file.Name, SHA256: hash})
+	}
+	sort.Slice(manifest.Entries, func(i, j int) bool {
+		return manifest.Entries[i].Path < manifest.Entries[j].Path
+	})
+	return manifest, nil
+}
+
+// String renders manifest in sha256sum format, so files extracted from zip can be checked with sha256sum -c.
+func (m *Manifest) String() string {
+	if m == nil {
+		return ""
+	}
+	var builder strings.Builder
+	for _, entry := range m.Entries {
+		builder.WriteString(fmt.Sprintf("%s  %s\n", entry.SHA256, entry.Path))
+	}
+	return builder.String()
+}
+
+// Hash is SHA-256 of the manifest, used as version of zipped files. Empty for nil manifest.
+func (m *Manifest) Hash() string {
+	if m == nil {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(m.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Text returns manifest for output, nil for nil manifest.
+func (m *Manifest) Text() *string {
+	if m == nil {
+		return nil
+	}
+	text := m.String()
+	return &text
+}
+
+func hashFile(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	_, err = io.Copy(hasher, reader)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
@@ -6,58 +6,76 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
+// All entries get the same modification time, the earliest one zip format can store, so zip content depends only
+// on files content and not on when or where they were written.
+var entryModified = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+const (
+	fileMode       os.FileMode = 0644
+	executableMode os.FileMode = 0755
+)
+
+type entry struct {
+	path     string
+	destPath string
+	mode     os.FileMode
+}
+
 // Zip returns paths added to zip & zip bytes or error. Inside path is path used inside zip, e.g. if out/test.txt
 // exists and out/ dir is zipped with insidePath src/, zip will contains src/test.txt.
-func Zip(dirPath string, insidePath string) ([]string, []byte, error) {
+// Zip is reproducible: entries are sorted, with fixed modification time and normalized permissions.
+// Files and directories matched by ignore are left out, ignore can be nil.
+func Zip(dirPath string, insidePath string, ignore *Ignore) ([]string, []byte, error) {
+	var entries []entry
+	err := walk(&entries, dirPath, dirPath, insidePath, ignore)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, fmt.Sprintf("walk directory %s", dirPath))
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].destPath < entries[j].destPath
+	})
+
 	buf := new(bytes.Buffer)
 	writer := zip.NewWriter(buf)
 	var files []string
+	added := make(map[string]bool)
 
-	err := filepath.WalkDir(dirPath, func(path string, dirEntry os.DirEntry, err error) error {
-		destPath := getDestPath(path, dirPath, insidePath)
-
-		if dirEntry == nil {
-			return fmt.Errorf("directory missing %s", dirPath)
+	for _, e := range entries {
+		// Same file can be reached through several symlinks
+		if added[e.destPath] {
+			continue
 		}
-		if dirEntry.IsDir() {
-			return nil
-		}
-		if dirEntry.Type() == os.ModeSymlink {
-			err := walkSymlink(writer, &files, path, destPath)
-			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("failed to walk symlinked dir %s", dirPath))
-			}
+		added[e.destPath] = true
 
-			return nil
-		}
+		dat, err := os.ReadFile(e.path)
 		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed to read path %s", path))
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed reading %s", e.path))
 		}
 
-		dat, err := os.ReadFile(path)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed reading %s", path))
+		header := &zip.FileHeader{
+			Name:     e.destPath,
+			Method:   zip.Deflate,
+			Modified: entryModified,
 		}
+		header.SetMode(e.mode)
 
-		destFile, err := writer.Create(destPath)
+		destFile, err := writer.CreateHeader(header)
 		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed creating %s", destPath))
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed creating %s", e.destPath))
 		}
 		_, err = destFile.Write(dat)
 		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed writing %s", destPath))
+			return nil, nil, errors.Wrap(err, fmt.Sprintf("failed writing %s", e.destPath))
 		}
-		files = append(files, path)
-
-		return nil
-	})
-	if err != nil {
-		return nil, nil, errors.Wrap(err, fmt.Sprintf("walk directory %s", dirPath))
+		files = append(files, e.path)
 	}
 
 	err = writer.Close()
@@ -68,24 +86,31 @@ func Zip(dirPath string, insidePath string) ([]string, []byte, error) {
 	return files, buf.Bytes(), nil
 }
 
-func walkSymlink(writer *zip.Writer, files *[]string, path string, destBasePath string) error {
-	evaluatedDirPath, err := filepath.EvalSymlinks(path)
-	if err != nil {
-		return errors.Wrap(err, fmt.Sprintf("failed to eval symlink %s", path))
-	}
+// walk adds files of dirPath to entries. Logical dir is path of dirPath before symlinks are evaluated,
+// ignore patterns are matched against it.
+func walk(entries *[]entry, dirPath string, logicalDir string, destBasePath string, ignore *Ignore) error {
+	return filepath.WalkDir(dirPath, func(path string, dirEntry os.DirEntry, err error) error {
+		destPath := getDestPath(path, dirPath, destBasePath)
+		logicalPath := filepath.Join(logicalDir, strings.TrimPrefix(path, dirPath))
 
-	return filepath.WalkDir(evaluatedDirPath, func(path string, dirEntry os.DirEntry, err error) error {
-		destPath := getDestPath(path, evaluatedDirPath, destBasePath)
 		if dirEntry == nil {
-			return fmt.Errorf("directory missing %s", evaluatedDirPath)
+			return fmt.Errorf("directory missing %s", dirPath)
 		}
 		if dirEntry.IsDir() {
+			if path != dirPath && ignore.Match(logicalPath, true) {
+				return filepath.SkipDir
+			}
 			return nil
 		}
 		if dirEntry.Type() == os.ModeSymlink {
-			err := walkSymlink(writer, files, path, destPath)
+			info, statErr := os.Stat(path)
+			if ignore.Match(logicalPath, statErr == nil && info.IsDir()) {
+				return nil
+			}
+
+			err := walkSymlink(entries, path, logicalPath, destPath, ignore)
 			if err != nil {
-				return errors.Wrap(err, fmt.Sprintf("failed to walk symlinked dir %s", evaluatedDirPath))
+				return errors.Wrap(err, fmt.Sprintf("failed to walk symlinked dir %s", dirPath))
 			}
 
 			return nil
@@ -93,26 +118,37 @@ func walkSymlink(writer *zip.Writer, files *[]string, path string, destBasePath
 		if err != nil {
 			return errors.Wrap(err, fmt.Sprintf("failed to read path %s", path))
 		}
-
-		dat, err := os.ReadFile(path)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed reading %s", path))
+		if ignore.Match(logicalPath, false) {
+			return nil
 		}
 
-		destFile, err := writer.Create(destPath)
+		info, err := dirEntry.Info()
 		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed creating %s", destPath))
-		}
-		_, err = destFile.Write(dat)
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("failed writing %s", destPath))
+			return errors.Wrap(err, fmt.Sprintf("failed to read path %s", path))
 		}
-		*files = append(*files, path)
+		*entries = append(*entries, entry{path: path, destPath: destPath, mode: normalizeMode(info.Mode())})
 
 		return nil
 	})
 }
 
+func walkSymlink(entries *[]entry, path string, logicalPath string, destBasePath string, ignore *Ignore) error {
+	evaluatedDirPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("failed to eval symlink %s", path))
+	}
+
+	return walk(entries, evaluatedDirPath, logicalPath, destBasePath, ignore)
+}
+
+// normalizeMode keeps only whether file is executable, other permission bits differ between machines and umasks.
+func normalizeMode(mode os.FileMode) os.FileMode {
+	if mode.Perm()&0111 != 0 {
+		return executableMode
+	}
+	return fileMode
+}
+
 /*
 Provides path that filePath will have in the output zip.
 filePath - Represents full path to the file (e.g. `node_modules/x/y.js`
@@ -123,5 +159,5 @@ For the example above, the output will be `nodejs/node_modules/x/y.js`.
 */
 func getDestPath(filePath string, dirPath string, destBasePath string) string {
 	relativePath := strings.TrimPrefix(filePath, dirPath)
-	return filepath.Join(destBasePath, relativePath)
+	return filepath.ToSlash(filepath.Join(destBasePath, relativePath))
 }
@@ -0,0 +1,224 @@
+package zip_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tenderlyZip "github.com/tenderly/tenderly-cli/zip"
+)
+
+func TestZipReproducible(t *testing.T) {
+	first := writeDir(t, time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC), 0600)
+	second := writeDir(t, time.Date(2022, time.June, 5, 12, 30, 0, 0, time.UTC), 0664)
+
+	_, firstZip, err := tenderlyZip.Zip(first, "nodejs/node_modules", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, secondZip, err := tenderlyZip.Zip(second, "nodejs/node_modules", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(firstZip, secondZip) {
+		t.Error("expected same zip for same content written at different time with different permissions")
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(firstZip), int64(len(firstZip)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"nodejs/node_modules/a/index.js",
+		"nodejs/node_modules/b/bin/cli",
+		"nodejs/node_modules/b/index.js",
+	}
+	if len(reader.File) != len(expected) {
+		t.Fatalf("expected %d entries, got %d", len(expected), len(reader.File))
+	}
+	for i, file := range reader.File {
+		if file.Name != expected[i] {
+			t.Errorf("expected entry %d to be %s, got %s", i, expected[i], file.Name)
+		}
+	}
+	if mode := reader.File[1].Mode().Perm(); mode != 0755 {
+		t.Errorf("expected executable mode 0755, got %o", mode)
+	}
+	if mode := reader.File[0].Mode().Perm(); mode != 0644 {
+		t.Errorf("expected mode 0644, got %o", mode)
+	}
+}
+
+func writeDir(t *testing.T, modified time.Time, mode os.FileMode) string {
+	dir := t.TempDir()
+	files := map[string]os.FileMode{
+		"b/index.js": mode,
+		"a/index.js": mode,
+		"b/bin/cli":  mode | 0100,
+	}
+	for name, fileMode := range files {
+		path := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(name), fileMode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Chtimes(path, modified, modified)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestZipIgnore(t *testing.T) {
+	root := t.TempDir()
+	files := []string{
+		"out/index.js",
+		"out/index.js.map",
+		"node_modules/ethers/lib/index.js",
+		"node_modules/ethers/README.md",
+		"node_modules/ethers/test/fixtures.json",
+		"node_modules/ethers/lib/test.js",
+	}
+	for _, name := range files {
+		path := filepath.Join(root, name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(path, []byte(name), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	ignore := tenderlyZip.NewIgnore(root, []byte("# comment\n*.map\n*.md\ntest/\n"))
+
+	tests := []struct {
+		dir        string
+		insidePath string
+		expected   []string
+	}{
+		{"out", "src/", []string{"src/index.js"}},
+		{
+			"node_modules", "nodejs/node_modules/",
+			[]string{"nodejs/node_modules/ethers/lib/index.js", "nodejs/node_modules/ethers/lib/test.js"},
+		},
+	}
+	for _, test := range tests {
+		_, content, err := tenderlyZip.Zip(filepath.Join(root, test.dir), test.insidePath, ignore)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reader.File) != len(test.expected) {
+			t.Fatalf("%s: expected %d entries, got %d", test.dir, len(test.expected), len(reader.File))
+		}
+		for i, file := range reader.File {
+			if file.Name != test.expected[i] {
+				t.Errorf("%s: expected entry %s, got %s", test.dir, test.expected[i], file.Name)
+			}
+		}
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]int{
+		"ethers/lib/index.js":            4000,
+		"ethers/node_modules/bn.js/a.js": 2000,
+		"@ethersproject/abi/index.js":    1000,
+		"tiny/index.js":                  10,
+	}
+	for name, size := range files {
+		path := filepath.Join(root, name)
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Random-looking content, so compressed sizes keep the order of sizes
+		content := make([]byte, size)
+		for i := range content {
+			content[i] = byte((i * 7919) % 251)
+		}
+		err = os.WriteFile(path, content, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, content, err := tenderlyZip.Zip(root, "nodejs/node_modules/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	analysis, err := tenderlyZip.Analyze(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if analysis.Bytes != len(content) || len(analysis.Files) != 4 {
+		t.Errorf("expected %d bytes and 4 files, got %d bytes and %d files", len(content), analysis.Bytes, len(analysis.Files))
+	}
+	expected := []struct {
+		name  string
+		files int
+	}{
+		{"ethers", 2},
+		{"@ethersproject/abi", 1},
+		{"tiny", 1},
+	}
+	if len(analysis.Packages) != len(expected) {
+		t.Fatalf("expected %d packages, got %+v", len(expected), analysis.Packages)
+	}
+	for i, pkg := range analysis.Packages {
+		if pkg.Name != expected[i].name || pkg.Files != expected[i].files {
+			t.Errorf("expected package %s with %d files, got %+v", expected[i].name, expected[i].files, pkg)
+		}
+	}
+}
+
+func TestManifest(t *testing.T) {
+	first := writeDir(t, time.Date(2021, time.March, 1, 10, 0, 0, 0, time.UTC), 0600)
+	second := writeDir(t, time.Date(2022, time.June, 5, 12, 30, 0, 0, time.UTC), 0664)
+
+	_, firstZip, err := tenderlyZip.Zip(first, "src/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, secondZip, err := tenderlyZip.Zip(second, "src/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstManifest, err := tenderlyZip.NewManifest(firstZip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondManifest, err := tenderlyZip.NewManifest(secondZip)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Content of every file is its name
+	expectedLine := "b5ae8679c762832479240254b956e65ed0572c6e139a7a6d6efac539e90f46db  src/a/index.js\n"
+	if !strings.HasPrefix(firstManifest.String(), expectedLine) || len(firstManifest.Entries) != 3 {
+		t.Errorf("unexpected manifest %s", firstManifest.String())
+	}
+	if firstManifest.Hash() != secondManifest.Hash() || len(firstManifest.Hash()) != 64 {
+		t.Errorf("expected same version for same files, got %s and %s", firstManifest.Hash(), secondManifest.Hash())
+	}
+
+	var missing *tenderlyZip.Manifest
+	if missing.Hash() != "" || missing.Text() != nil {
+		t.Error("expected empty version and no manifest for missing directory")
+	}
+}